@@ -0,0 +1,255 @@
+package fraudsdkgo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+)
+
+// AccessList describes the accounts, the IP addresses or CIDR ranges, and the ClientIDs of a list.
+type AccessList struct {
+	Accounts  []string `json:"accounts,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+	ClientIDs []string `json:"clientIDs,omitempty"`
+}
+
+// AccessLists describes the allow and deny lists consulted before sending the events to the Account Protect API,
+// e.g. to never send the QA accounts, the monitoring probes or the office IP ranges, or to deny known-bad IPs.
+//
+// The events matching a list are not sent: the validations return a [ResponsePayload] with the [Allowlisted]
+// status and the [Allow] action, or the [Denylisted] status and the [Deny] action.
+// The deny list takes precedence over the allow list.
+//
+// The IP lists only match the IPs that cannot be forged by the emitter (see [ClientWithTrustedProxies]).
+// The ClientIDs are sent by the emitter: allow-listing a ClientID trusts anyone presenting it.
+type AccessLists struct {
+	Allow AccessList `json:"allow"`
+	Deny  AccessList `json:"deny"`
+}
+
+// compiledAccessList stores an [AccessList] in a form suitable for the lookups.
+type compiledAccessList struct {
+	accounts  map[string]bool
+	clientIDs map[string]bool
+	networks  []netip.Prefix
+}
+
+// accessListsStore stores the [AccessLists] of the [Client], which may be replaced at runtime.
+// The digest is the SHA-256 hash of the content of the file the lists were loaded from, if any.
+type accessListsStore struct {
+	mu     sync.RWMutex
+	allow  compiledAccessList
+	deny   compiledAccessList
+	digest [sha256.Size]byte
+}
+
+// LoadAccessLists reads the [AccessLists] from the JSON file, e.g.:
+//
+//	{
+//	  "allow": {"accounts": ["qa@example.com"], "ips": ["203.0.113.0/24"]},
+//	  "deny": {"ips": ["198.51.100.7"], "clientIDs": ["known-bad-client-id"]}
+//	}
+//
+// An error wrapping [ErrWrongAccessListsValue] is returned if the file describes invalid lists.
+func LoadAccessLists(path string) (*AccessLists, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read access lists: %w", err)
+	}
+	return parseAccessLists(data)
+}
+
+// parseAccessLists decodes the [AccessLists] from the content of a JSON file (see [LoadAccessLists]).
+func parseAccessLists(data []byte) (*AccessLists, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	lists := &AccessLists{}
+	if err := decoder.Decode(lists); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongAccessListsValue, err)
+	}
+	if _, err := lists.Allow.compile(); err != nil {
+		return nil, err
+	}
+	if _, err := lists.Deny.compile(); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// compile returns the [compiledAccessList] of the list.
+// An error wrapping [ErrWrongAccessListsValue] is returned if an IP address or a CIDR range cannot be parsed.
+func (l *AccessList) compile() (compiledAccessList, error) {
+	networks, err := parseNetworks(l.IPs, ErrWrongAccessListsValue)
+	if err != nil {
+		return compiledAccessList{}, err
+	}
+	compiled := compiledAccessList{
+		accounts:  make(map[string]bool, len(l.Accounts)),
+		clientIDs: make(map[string]bool, len(l.ClientIDs)),
+		networks:  networks,
+	}
+	for _, account := range l.Accounts {
+		compiled.accounts[account] = true
+	}
+	for _, clientID := range l.ClientIDs {
+		compiled.clientIDs[clientID] = true
+	}
+	return compiled, nil
+}
+
+// match returns whether the account, the IP or the ClientID belongs to the list.
+func (l *compiledAccessList) match(account string, ip string, clientID string) bool {
+	if account != "" && l.accounts[account] {
+		return true
+	}
+	if clientID != "" && l.clientIDs[clientID] {
+		return true
+	}
+	if addr, ok := parseIP(ip); ok && isTrustedIP(addr, l.networks) {
+		return true
+	}
+	return false
+}
+
+// set replaces the lists of the store.
+func (s *accessListsStore) set(lists *AccessLists, digest [sha256.Size]byte) error {
+	allow, err := lists.Allow.compile()
+	if err != nil {
+		return err
+	}
+	deny, err := lists.Deny.compile()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allow = allow
+	s.deny = deny
+	s.digest = digest
+	return nil
+}
+
+// load replaces the lists of the store by the ones of the file.
+func (s *accessListsStore) load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("fail to read access lists: %w", err)
+	}
+	return s.loadData(data)
+}
+
+// loadData replaces the lists of the store by the ones of the content of the file.
+func (s *accessListsStore) loadData(data []byte) error {
+	lists, err := parseAccessLists(data)
+	if err != nil {
+		return err
+	}
+	return s.set(lists, sha256.Sum256(data))
+}
+
+// lookup returns the synthetic [ResponsePayload] of the list matching the account, the IP or the ClientID,
+// or nil if none of the lists matches.
+func (s *accessListsStore) lookup(account string, ip string, clientID string) *ResponsePayload {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.deny.match(account, ip, clientID) {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Deny,
+				Status: Denylisted,
+			},
+		}
+	}
+	if s.allow.match(account, ip, clientID) {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Allowlisted,
+			},
+		}
+	}
+	return nil
+}
+
+// matchAccessLists returns the synthetic [ResponsePayload] of the [AccessLists] of the [Client] matching
+// the account, the IP or the ClientID of the [Header], or nil if the event must be sent.
+//
+// The IP lists only match the IPs that cannot be forged by the emitter: the RemoteAddr, the IPs resolved
+// through the trusted proxies (see [ClientWithTrustedProxies] and [ClientWithTrustedHops]) and the Addr of the
+// [RequestMetadata]. The headers of the [CDN] are only considered when trusted proxies are configured, and
+// the [Client.FallbackIP] never matches.
+func (c *Client) matchAccessLists(account string, header *Header) *ResponsePayload {
+	if c.accessLists == nil {
+		return nil
+	}
+	return c.accessLists.lookup(account, header.resolvedAddr, header.ClientID)
+}
+
+// SetAccessLists replaces the [AccessLists] of the [Client] at runtime.
+// An error wrapping [ErrWrongAccessListsValue] is returned if the lists are invalid, the previous lists are kept.
+func (c *Client) SetAccessLists(lists AccessLists) error {
+	if c.accessLists == nil {
+		c.accessLists = &accessListsStore{}
+	}
+	return c.accessLists.set(&lists, [sha256.Size]byte{})
+}
+
+// ReloadAccessLists reads again the file set with [ClientWithAccessListsFile].
+// The previous lists are kept if the file cannot be read or describes invalid lists.
+// [ErrAccessListsNotLoaded] is returned if the [Client] was not created with [NewClient].
+func (c *Client) ReloadAccessLists() error {
+	if c.AccessListsFile == "" {
+		return fmt.Errorf("%w: no access lists file", ErrWrongAccessListsValue)
+	}
+	if c.accessLists == nil {
+		return ErrAccessListsNotLoaded
+	}
+	return c.accessLists.load(c.AccessListsFile)
+}
+
+// WatchAccessLists checks the content of the file set with [ClientWithAccessListsFile] at each interval,
+// and reloads the lists when the file has been modified. It blocks until the context is done, so it is usually
+// run in its own goroutine. The errors, e.g. an invalid file, are passed to the onError function if not nil.
+func (c *Client) WatchAccessLists(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.reloadModifiedAccessLists(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// reloadModifiedAccessLists reloads the access lists file if its content changed since the last load.
+// The content is compared rather than the modification time, which misses the rewrites within the resolution
+// of the timestamps of the filesystem or the copies preserving it.
+func (c *Client) reloadModifiedAccessLists() error {
+	if c.AccessListsFile == "" {
+		return fmt.Errorf("%w: no access lists file", ErrWrongAccessListsValue)
+	}
+	if c.accessLists == nil {
+		return ErrAccessListsNotLoaded
+	}
+	data, err := os.ReadFile(c.AccessListsFile)
+	if err != nil {
+		return fmt.Errorf("fail to read access lists: %w", err)
+	}
+	c.accessLists.mu.RLock()
+	modified := sha256.Sum256(data) != c.accessLists.digest
+	c.accessLists.mu.RUnlock()
+	if !modified {
+		return nil
+	}
+	return c.accessLists.loadData(data)
+}
@@ -0,0 +1,308 @@
+package fraudsdkgo
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeAccessListsFile(t *testing.T, path string, content string, modTime time.Time) {
+	t.Helper()
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	assert.Nil(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestLoadAccessLists(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"Valid lists", `{"allow": {"accounts": ["qa@example.com"], "ips": ["203.0.113.0/24"]}, "deny": {"clientIDs": ["bad"]}}`, nil},
+		{"Invalid JSON", `{"allow": [`, ErrWrongAccessListsValue},
+		{"Unknown field", `{"allow": {"emails": ["qa@example.com"]}}`, ErrWrongAccessListsValue},
+		{"Invalid IP", `{"deny": {"ips": ["not-an-ip"]}}`, ErrWrongAccessListsValue},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "lists.json")
+			writeAccessListsFile(t, path, tc.content, time.Now())
+
+			lists, err := LoadAccessLists(path)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.Equal(t, []string{"qa@example.com"}, lists.Allow.Accounts)
+				assert.Equal(t, []string{"bad"}, lists.Deny.ClientIDs)
+			}
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadAccessLists(filepath.Join(dir, "missing.json"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestAccessListsLookup(t *testing.T) {
+	store := &accessListsStore{}
+	err := store.set(&AccessLists{
+		Allow: AccessList{Accounts: []string{"qa@example.com"}, IPs: []string{"203.0.113.0/24"}, ClientIDs: []string{"probe"}},
+		Deny:  AccessList{IPs: []string{"198.51.100.7", "2001:db8::/32"}},
+	}, [sha256.Size]byte{})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		account  string
+		ip       string
+		clientID string
+		want     ResponseStatus
+	}{
+		{"Allowed account", "qa@example.com", "192.0.2.1", "", Allowlisted},
+		{"Allowed IP range", "john@example.com", "203.0.113.42", "", Allowlisted},
+		{"Allowed ClientID", "john@example.com", "192.0.2.1", "probe", Allowlisted},
+		{"Denied IP", "john@example.com", "198.51.100.7", "", Denylisted},
+		{"Denied IPv6 range", "john@example.com", "2001:db8::1", "", Denylisted},
+		{"Deny takes precedence", "qa@example.com", "198.51.100.7", "", Denylisted},
+		{"Not listed", "john@example.com", "192.0.2.1", "", ""},
+		{"Missing IP", "john@example.com", "", "", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := store.lookup(tc.account, tc.ip, tc.clientID)
+			if tc.want == "" {
+				assert.Nil(t, resp)
+				return
+			}
+			if assert.NotNil(t, resp) {
+				assert.Equal(t, tc.want, resp.Status)
+				if tc.want == Denylisted {
+					assert.Equal(t, Deny, resp.Action)
+				} else {
+					assert.Equal(t, Allow, resp.Action)
+				}
+			}
+		})
+	}
+}
+
+func TestClientWithAccessLists(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"action":"allow"}`))
+	}))
+	defer server.Close()
+
+	t.Run("Invalid lists", func(t *testing.T) {
+		_, err := NewClient("your-api-key", ClientWithAccessLists(AccessLists{Deny: AccessList{IPs: []string{"10.0.0.0/33"}}}))
+		assert.ErrorIs(t, err, ErrWrongAccessListsValue)
+	})
+
+	t.Run("Listed events are not sent", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL), ClientWithAccessLists(AccessLists{
+			Allow: AccessList{Accounts: []string{"qa@example.com"}},
+		}))
+		assert.Nil(t, err)
+
+		resp, err := c.Validate(setupRequest(), NewLoginEvent("qa@example.com", Succeeded))
+		assert.Nil(t, err)
+		assert.Equal(t, Allowlisted, resp.Status)
+		errResp, err := c.Collect(setupRequest(), NewLoginEvent("qa@example.com", Succeeded))
+		assert.Nil(t, err)
+		assert.Nil(t, errResp)
+		assert.Equal(t, 0, calls)
+
+		resp, err = c.Validate(setupRequest(), NewLoginEvent("john@example.com", Succeeded))
+		assert.Nil(t, err)
+		assert.Equal(t, OK, resp.Status)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Custom events", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithAccessLists(AccessLists{
+			Allow: AccessList{Accounts: []string{"qa@example.com"}},
+			Deny:  AccessList{IPs: []string{"198.51.100.0/24"}},
+		}))
+		assert.Nil(t, err)
+
+		resp, err := c.Validate(setupRequest(), &describedEvent{account: "qa@example.com"})
+		assert.Nil(t, err)
+		assert.Equal(t, Allowlisted, resp.Status)
+
+		r := setupRequest()
+		r.RemoteAddr = "198.51.100.7:1234"
+		resp, err = c.Validate(r, &MockEvent{})
+		assert.Nil(t, err)
+		assert.Equal(t, Denylisted, resp.Status)
+
+		addr, method := "198.51.100.7", http.MethodPost
+		errResp, err := c.CollectFromMetadata(context.Background(), &MockEvent{}, &RequestMetadata{Addr: &addr, Method: &method})
+		assert.Nil(t, err)
+		assert.Nil(t, errResp)
+	})
+
+	t.Run("Lists replaced at runtime", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+		assert.Nil(t, err)
+
+		r := setupRequest()
+		r.RemoteAddr = "198.51.100.7:1234"
+		assert.Nil(t, c.SetAccessLists(AccessLists{Deny: AccessList{IPs: []string{"198.51.100.0/24"}}}))
+		resp, err := c.Validate(r, NewRegistrationEvent("john@example.com", User{ID: "123456"}))
+		assert.Nil(t, err)
+		assert.Equal(t, Deny, resp.Action)
+		assert.Equal(t, Denylisted, resp.Status)
+
+		assert.ErrorIs(t, c.SetAccessLists(AccessLists{Deny: AccessList{IPs: []string{"invalid"}}}), ErrWrongAccessListsValue)
+		resp, _ = c.Validate(r, NewRegistrationEvent("john@example.com", User{ID: "123456"}))
+		assert.Equal(t, Denylisted, resp.Status)
+	})
+}
+
+func TestClientWithAccessLists_ForgedIP(t *testing.T) {
+	lists := AccessLists{Allow: AccessList{IPs: []string{"203.0.113.0/24"}}}
+
+	tests := []struct {
+		name    string
+		options []ClientOption
+		headers map[string]string
+		want    bool
+	}{
+		{"Forged X-Forwarded-For without configuration", nil, map[string]string{"X-Forwarded-For": "203.0.113.1"}, false},
+		{"Forged Forwarded without configuration", nil, map[string]string{"Forwarded": "for=203.0.113.1"}, false},
+		{"Forged Forwarded with trusted proxies", []ClientOption{ClientWithTrustedProxies([]string{"10.0.0.0/8"})}, map[string]string{"Forwarded": "for=203.0.113.1", "X-Forwarded-For": "198.51.100.1"}, false},
+		{"Forged CDN header without trusted proxies", []ClientOption{ClientWithCDN(Cloudflare)}, map[string]string{"CF-Connecting-IP": "203.0.113.1"}, false},
+		{"Resolved through the trusted proxies", []ClientOption{ClientWithTrustedProxies([]string{"10.0.0.0/8"})}, map[string]string{"X-Forwarded-For": "203.0.113.1"}, true},
+		{"CDN header with trusted proxies", []ClientOption{ClientWithTrustedProxies([]string{"10.0.0.0/8"}), ClientWithCDN(Cloudflare)}, map[string]string{"CF-Connecting-IP": "203.0.113.1"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClient("your-api-key", append(tc.options, ClientWithAccessLists(lists))...)
+			assert.Nil(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/login", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			for name, value := range tc.headers {
+				r.Header.Set(name, value)
+			}
+			header, err := c.buildHeader(r, &RequestMetadata{})
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.want, c.matchAccessLists("john@example.com", header) != nil)
+		})
+	}
+
+	t.Run("Fallback IP", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithFallbackIP("203.0.113.1"), ClientWithAccessLists(lists))
+		assert.Nil(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/login", nil)
+		r.RemoteAddr = "@"
+		header, err := c.buildHeader(r, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "203.0.113.1", header.Addr)
+		assert.Nil(t, c.matchAccessLists("john@example.com", header))
+	})
+}
+
+func TestClientWithAccessListsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lists.json")
+	modTime := time.Now().Add(-time.Hour)
+	writeAccessListsFile(t, path, `{"allow": {"accounts": ["qa@example.com"]}}`, modTime)
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := NewClient("your-api-key", ClientWithAccessListsFile(path+".missing"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	c, err := NewClient("your-api-key", ClientWithAccessListsFile(path))
+	assert.Nil(t, err)
+	assert.NotNil(t, c.matchAccessLists("qa@example.com", &Header{}))
+
+	t.Run("Reload", func(t *testing.T) {
+		writeAccessListsFile(t, path, `{"allow": {"accounts": ["probe@example.com"]}}`, modTime)
+		assert.Nil(t, c.ReloadAccessLists())
+		assert.Nil(t, c.matchAccessLists("qa@example.com", &Header{}))
+		assert.NotNil(t, c.matchAccessLists("probe@example.com", &Header{}))
+
+		writeAccessListsFile(t, path, `{"allow": [`, modTime)
+		assert.ErrorIs(t, c.ReloadAccessLists(), ErrWrongAccessListsValue)
+		assert.NotNil(t, c.matchAccessLists("probe@example.com", &Header{}))
+	})
+
+	t.Run("Reload only modified file", func(t *testing.T) {
+		writeAccessListsFile(t, path, `{"allow": {"accounts": ["probe@example.com"]}}`, modTime)
+		assert.Nil(t, c.ReloadAccessLists())
+
+		assert.Nil(t, c.reloadModifiedAccessLists())
+		assert.NotNil(t, c.matchAccessLists("probe@example.com", &Header{}))
+
+		// same size and same modification time
+		writeAccessListsFile(t, path, `{"allow": {"accounts": ["qa123@example.com"]}}`, modTime)
+		assert.Nil(t, c.reloadModifiedAccessLists())
+		assert.Nil(t, c.matchAccessLists("probe@example.com", &Header{}))
+		assert.NotNil(t, c.matchAccessLists("qa123@example.com", &Header{}))
+
+		writeAccessListsFile(t, path, `{"allow": {"accounts": ["qa@example.com"]}}`, modTime.Add(time.Minute))
+		assert.Nil(t, c.reloadModifiedAccessLists())
+		assert.NotNil(t, c.matchAccessLists("qa@example.com", &Header{}))
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		writeAccessListsFile(t, path, `{"deny": {"accounts": ["fraud@example.com"]}}`, modTime.Add(2*time.Minute))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			c.WatchAccessLists(ctx, 10*time.Millisecond, nil)
+			close(done)
+		}()
+		assert.Eventually(t, func() bool {
+			return c.matchAccessLists("fraud@example.com", &Header{}) != nil
+		}, time.Second, 10*time.Millisecond)
+		cancel()
+		<-done
+	})
+
+	t.Run("Without file", func(t *testing.T) {
+		c, err := NewClient("your-api-key")
+		assert.Nil(t, err)
+		assert.ErrorIs(t, c.ReloadAccessLists(), ErrWrongAccessListsValue)
+	})
+
+	t.Run("Without NewClient", func(t *testing.T) {
+		c := &Client{AccessListsFile: path}
+		assert.Equal(t, ErrAccessListsNotLoaded, c.ReloadAccessLists())
+		assert.Equal(t, ErrAccessListsNotLoaded, c.reloadModifiedAccessLists())
+	})
+}
+
+func ExampleClientWithAccessLists() {
+	c, err := NewClient("your-api-key", ClientWithAccessLists(AccessLists{
+		Allow: AccessList{Accounts: []string{"qa@example.com"}, IPs: []string{"203.0.113.0/24"}},
+		Deny:  AccessList{IPs: []string{"198.51.100.7"}},
+	}))
+	if err != nil {
+		return
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/login", nil)
+	r.RemoteAddr = "198.51.100.7:1234"
+	resp, _ := c.Validate(r, NewLoginEvent("john@example.com", Succeeded))
+	fmt.Println(resp.Action, resp.Status)
+	// Output: deny denylisted
+}
@@ -48,6 +48,21 @@ func NewAccountUpdateEvent(account string, options ...AccountUpdateOption) *Acco
 	return event
 }
 
+// Check returns the problems found in the fields of the [AccountUpdateEvent] (see [Checker]).
+func (e *AccountUpdateEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	pc.authentication("authentication", e.Authentication)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [AccountUpdateEvent] (see [Describer]).
+func (e *AccountUpdateEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
 // Validate is used to construct the [AccountUpdateRequestPayload] based on the information stored
 // in the [NewAccountUpdateEvent] structure and performs the validation request to the Account Protect API.
 // An error may be returned in case of error when performing the request.
@@ -0,0 +1,176 @@
+package fraudsdkgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CDN describes the headers set by a Content Delivery Network placed in front of the application.
+// The empty fields are ignored.
+//
+// Presets are available for the most common CDNs, and custom values can be defined for the others.
+type CDN struct {
+	// Name is the name of the CDN.
+	Name string
+	// ClientIPHeader is the header containing the IP of the emitter.
+	// The value may contain a port if the IPv6 addresses are enclosed in brackets (e.g. `[2001:db8::1]:443`).
+	ClientIPHeader string
+	// ClientIPWithPort specifies whether the value of the ClientIPHeader always ends with a port,
+	// the IPv6 addresses not being enclosed in brackets (e.g. `2001:db8::1:46532` for `CloudFront-Viewer-Address`).
+	ClientIPWithPort bool
+	// ProtocolHeader is the header containing the protocol of the request: `http` or `https`, `on` or `off`,
+	// `1` or `0` (e.g. `Fastly-SSL`), or a JSON object with a `scheme` field (e.g. `CF-Visitor`).
+	ProtocolHeader string
+	// PortHeader is the header containing the port of the request.
+	// The value may be preceded by an IP address (e.g. `CloudFront-Viewer-Address`).
+	PortHeader string
+	// CountryCodeHeader is the header containing the ISO 3166-1 alpha-2 country code of the emitter.
+	CountryCodeHeader string
+	// RegionCodeHeader is the header containing the region code of the emitter.
+	RegionCodeHeader string
+	// CityHeader is the header containing the city of the emitter.
+	CityHeader string
+	// MapGeolocation specifies whether the geolocation headers are sent to the Account Protect API.
+	MapGeolocation bool
+}
+
+// Presets of the headers set by the most common CDNs.
+//
+// The headers are set by the CDN, but nothing prevents a client reaching the application without going through
+// the CDN from setting them: without [ClientWithTrustedProxies], the headers of the CDN are read from any request.
+var (
+	Akamai = CDN{
+		Name:           "akamai",
+		ClientIPHeader: "True-Client-IP",
+		ProtocolHeader: "X-Forwarded-Proto",
+		PortHeader:     "X-Forwarded-Port",
+	}
+	AzureFrontDoor = CDN{
+		Name:           "azure-front-door",
+		ClientIPHeader: "X-Azure-ClientIP",
+		ProtocolHeader: "X-Forwarded-Proto",
+		PortHeader:     "X-Forwarded-Port",
+	}
+	Cloudflare = CDN{
+		Name:              "cloudflare",
+		ClientIPHeader:    "CF-Connecting-IP",
+		ProtocolHeader:    "CF-Visitor",
+		CountryCodeHeader: "CF-IPCountry",
+		RegionCodeHeader:  "CF-Region-Code",
+		CityHeader:        "CF-IPCity",
+	}
+	CloudFront = CDN{
+		Name:              "cloudfront",
+		ClientIPHeader:    "CloudFront-Viewer-Address",
+		ClientIPWithPort:  true,
+		ProtocolHeader:    "CloudFront-Forwarded-Proto",
+		PortHeader:        "CloudFront-Viewer-Address",
+		CountryCodeHeader: "CloudFront-Viewer-Country",
+		RegionCodeHeader:  "CloudFront-Viewer-Country-Region",
+		CityHeader:        "CloudFront-Viewer-City",
+	}
+	Fastly = CDN{
+		Name:           "fastly",
+		ClientIPHeader: "Fastly-Client-IP",
+		ProtocolHeader: "Fastly-SSL",
+		PortHeader:     "Fastly-Client-Port",
+	}
+)
+
+// WithGeolocation returns a copy of the [CDN] that sends the geolocation headers to the Account Protect API.
+func (cdn CDN) WithGeolocation() CDN {
+	cdn.MapGeolocation = true
+	return cdn
+}
+
+// getClientIP returns the IP of the emitter found in the client IP header of the CDN.
+// It returns an empty string if the header is missing or invalid.
+func (cdn *CDN) getClientIP(r *http.Request) string {
+	if cdn.ClientIPHeader == "" {
+		return ""
+	}
+	value := strings.TrimSpace(r.Header.Get(cdn.ClientIPHeader))
+	if value == "" {
+		return ""
+	}
+	if cdn.ClientIPWithPort {
+		index := strings.LastIndex(value, ":")
+		if index <= 0 {
+			return ""
+		}
+		value = value[:index]
+	}
+	addr, ok := parseIP(value)
+	if !ok {
+		return ""
+	}
+	return addr.String()
+}
+
+// getProtocol returns the protocol found in the protocol header of the CDN.
+// It returns an empty string if the header is missing or invalid.
+func (cdn *CDN) getProtocol(r *http.Request) string {
+	if cdn.ProtocolHeader == "" {
+		return ""
+	}
+	value := strings.TrimSpace(r.Header.Get(cdn.ProtocolHeader))
+	if strings.HasPrefix(value, "{") {
+		var visitor struct {
+			Scheme string `json:"scheme"`
+		}
+		if err := json.Unmarshal([]byte(value), &visitor); err != nil {
+			return ""
+		}
+		value = visitor.Scheme
+	}
+	switch strings.ToLower(value) {
+	case "https", "on", "1":
+		return "https"
+	case "http", "off", "0":
+		return "http"
+	}
+	return ""
+}
+
+// getPort returns the port found in the port header of the CDN, or -1 if the header is missing or invalid.
+func (cdn *CDN) getPort(r *http.Request) int {
+	if cdn.PortHeader == "" {
+		return -1
+	}
+	value := strings.TrimSpace(r.Header.Get(cdn.PortHeader))
+	if index := strings.LastIndex(value, ":"); index >= 0 {
+		value = value[index+1:]
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port <= 0 || port > 65535 {
+		return -1
+	}
+	return port
+}
+
+// getLocation returns the [Location] of the emitter found in the geolocation headers of the CDN.
+// It returns nil if the geolocation is not mapped or if none of the headers is present.
+func (cdn *CDN) getLocation(r *http.Request, t *truncator) *Location {
+	if !cdn.MapGeolocation {
+		return nil
+	}
+	location := &Location{
+		City:        cdn.getOptionalHeader(r, t, cdn.CityHeader),
+		CountryCode: cdn.getOptionalHeader(r, t, cdn.CountryCodeHeader),
+		RegionCode:  cdn.getOptionalHeader(r, t, cdn.RegionCodeHeader),
+	}
+	if location.City == nil && location.CountryCode == nil && location.RegionCode == nil {
+		return nil
+	}
+	return location
+}
+
+// getOptionalHeader returns a pointer to the value of the header, or nil if the header is not defined or empty.
+func (cdn *CDN) getOptionalHeader(r *http.Request, t *truncator, name string) *string {
+	if name == "" {
+		return nil
+	}
+	return t.pointerValue(CDNLocation, strings.TrimSpace(r.Header.Get(name)))
+}
@@ -0,0 +1,160 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCDN_GetClientIP(t *testing.T) {
+	tests := []struct {
+		name  string
+		cdn   CDN
+		key   string
+		value string
+		want  string
+	}{
+		{"Akamai", Akamai, "True-Client-IP", "203.0.113.1", "203.0.113.1"},
+		{"Azure Front Door", AzureFrontDoor, "X-Azure-ClientIP", "203.0.113.1", "203.0.113.1"},
+		{"Cloudflare IPv6", Cloudflare, "CF-Connecting-IP", "2001:db8::1", "2001:db8::1"},
+		{"Cloudflare compressed IPv6", Cloudflare, "CF-Connecting-IP", "2001:db8::", "2001:db8::"},
+		{"Cloudflare IPv6 with port", Cloudflare, "CF-Connecting-IP", "[2001:db8::1]:443", "2001:db8::1"},
+		{"Cloudflare IPv4 with port", Cloudflare, "CF-Connecting-IP", "203.0.113.1:443", "203.0.113.1"},
+		{"CloudFront IPv4 with port", CloudFront, "CloudFront-Viewer-Address", "203.0.113.1:46532", "203.0.113.1"},
+		{"CloudFront IPv6 with port", CloudFront, "CloudFront-Viewer-Address", "2001:db8::1:46532", "2001:db8::1"},
+		{"CloudFront without port", CloudFront, "CloudFront-Viewer-Address", "203.0.113.1", ""},
+		{"Fastly", Fastly, "Fastly-Client-IP", "203.0.113.1", "203.0.113.1"},
+		{"Invalid value", Cloudflare, "CF-Connecting-IP", "unknown", ""},
+		{"Missing header", Cloudflare, "X-Other", "203.0.113.1", ""},
+		{"Without client IP header", CDN{}, "CF-Connecting-IP", "203.0.113.1", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			r.Header.Set(tc.key, tc.value)
+			assert.Equal(t, tc.want, tc.cdn.getClientIP(r))
+		})
+	}
+}
+
+func TestCDN_GetProtocolAndPort(t *testing.T) {
+	cdn := CDN{ProtocolHeader: "X-CDN-Proto", PortHeader: "X-CDN-Port"}
+
+	r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	assert.Equal(t, "", cdn.getProtocol(r))
+	assert.Equal(t, -1, cdn.getPort(r))
+
+	r.Header.Set("X-CDN-Proto", "HTTPS")
+	r.Header.Set("X-CDN-Port", "8443")
+	assert.Equal(t, "https", cdn.getProtocol(r))
+	assert.Equal(t, 8443, cdn.getPort(r))
+
+	r.Header.Set("X-CDN-Proto", "ftp")
+	r.Header.Set("X-CDN-Port", "99999")
+	assert.Equal(t, "", cdn.getProtocol(r))
+	assert.Equal(t, -1, cdn.getPort(r))
+}
+
+func TestCDN_Presets_GetProtocolAndPort(t *testing.T) {
+	tests := []struct {
+		name      string
+		cdn       CDN
+		headers   map[string]string
+		wantProto string
+		wantPort  int
+	}{
+		{"Akamai", Akamai, map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Port": "443"}, "https", 443},
+		{"Azure Front Door", AzureFrontDoor, map[string]string{"X-Forwarded-Proto": "http", "X-Forwarded-Port": "80"}, "http", 80},
+		{"Cloudflare", Cloudflare, map[string]string{"CF-Visitor": `{"scheme":"https"}`}, "https", -1},
+		{"Cloudflare invalid visitor", Cloudflare, map[string]string{"CF-Visitor": `{"scheme":`}, "", -1},
+		{"CloudFront", CloudFront, map[string]string{"CloudFront-Forwarded-Proto": "https", "CloudFront-Viewer-Address": "2001:db8::1:46532"}, "https", 46532},
+		{"Fastly TLS", Fastly, map[string]string{"Fastly-SSL": "1", "Fastly-Client-Port": "46532"}, "https", 46532},
+		{"Fastly plaintext", Fastly, map[string]string{"Fastly-SSL": "0"}, "http", -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			for key, value := range tc.headers {
+				r.Header.Set(key, value)
+			}
+			assert.Equal(t, tc.wantProto, tc.cdn.getProtocol(r))
+			assert.Equal(t, tc.wantPort, tc.cdn.getPort(r))
+		})
+	}
+}
+
+func TestCDN_GetLocation(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	r.Header.Set("CloudFront-Viewer-Country", "FR")
+	r.Header.Set("CloudFront-Viewer-Country-Region", "IDF")
+	r.Header.Set("CloudFront-Viewer-City", "Paris")
+
+	assert.Nil(t, CloudFront.getLocation(r, &truncator{}))
+
+	cdn := CloudFront.WithGeolocation()
+	location := cdn.getLocation(r, &truncator{})
+	assert.NotNil(t, location)
+	assert.Equal(t, "FR", *location.CountryCode)
+	assert.Equal(t, "IDF", *location.RegionCode)
+	assert.Equal(t, "Paris", *location.City)
+	assert.False(t, CloudFront.MapGeolocation)
+
+	r = httptest.NewRequest(http.MethodGet, "/ping", nil)
+	assert.Nil(t, cdn.getLocation(r, &truncator{}))
+}
+
+func TestWithCDN(t *testing.T) {
+	t.Run("Reads the headers of the CDN", func(t *testing.T) {
+		request := setupRequest()
+		request.Header.Set("CloudFront-Viewer-Address", "203.0.113.1:46532")
+		request.Header.Set("CloudFront-Forwarded-Proto", "https")
+		request.Header.Set("CloudFront-Viewer-Country", "FR")
+		c, err := NewClient("your-api-key", ClientWithCDN(CloudFront.WithGeolocation()))
+		assert.Nil(t, err)
+
+		header, err := c.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "203.0.113.1", header.Addr)
+		assert.Equal(t, "https", header.Protocol)
+		assert.Equal(t, 46532, header.Port)
+		assert.NotNil(t, header.CDNLocation)
+		assert.Equal(t, "FR", *header.CDNLocation.CountryCode)
+	})
+
+	t.Run("Falls back when the header is missing", func(t *testing.T) {
+		request := setupRequest()
+		c, err := NewClient("your-api-key", ClientWithCDN(Cloudflare))
+		assert.Nil(t, err)
+
+		header, err := c.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "127.0.0.1", header.Addr)
+		assert.Nil(t, header.CDNLocation)
+	})
+
+	t.Run("Ignores the headers when the RemoteAddr is not trusted", func(t *testing.T) {
+		request := setupRequest()
+		request.Header.Set("CF-Connecting-IP", "203.0.113.1")
+		c, err := NewClient("your-api-key", ClientWithCDN(Cloudflare), ClientWithTrustedProxies([]string{"10.0.0.0/8"}))
+		assert.Nil(t, err)
+
+		header, err := c.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "127.0.0.1", header.Addr)
+	})
+}
+
+func ExampleClientWithCDN() {
+	c, _ := NewClient("your-api-key", ClientWithCDN(Cloudflare.WithGeolocation()))
+
+	fmt.Println(c.CDN.ClientIPHeader)
+	fmt.Println(c.CDN.MapGeolocation)
+	// Output:
+	// CF-Connecting-IP
+	// true
+}
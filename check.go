@@ -0,0 +1,161 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Checker is implemented by the events able to check their fields before being sent to the Account Protect API.
+// All the events of this package implement it. When [ClientWithStrictValidation] is used, the events
+// reporting problems are not sent.
+type Checker interface {
+	// Check returns the problems found in the fields of the event, with the same shape as the errors
+	// returned by the Account Protect API. The Field is the JSON path of the field in the payload.
+	Check() []ErrorInfo
+}
+
+// currencyRegexp matches the ISO 4217 currency codes.
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// payloadChecker accumulates the problems found in the fields of an event.
+type payloadChecker struct {
+	errors []ErrorInfo
+}
+
+// add reports a problem for the field.
+func (pc *payloadChecker) add(field string, format string, args ...interface{}) {
+	pc.errors = append(pc.errors, ErrorInfo{Field: field, Error: fmt.Sprintf(format, args...)})
+}
+
+// result returns the problems found, or nil if the event is valid.
+func (pc *payloadChecker) result() []ErrorInfo {
+	return pc.errors
+}
+
+// required reports a problem if the value is empty.
+func (pc *payloadChecker) required(field string, value string) {
+	if value == "" {
+		pc.add(field, "must not be empty")
+	}
+}
+
+// positive reports a problem if the value is negative.
+func (pc *payloadChecker) positive(field string, value int) {
+	if value < 0 {
+		pc.add(field, "must be a positive integer")
+	}
+}
+
+// countryCode reports a problem if the value is not an ISO 3166-1 alpha-2 code.
+func (pc *payloadChecker) countryCode(field string, value *string) {
+	if value == nil {
+		return
+	}
+	if !countryCodes[*value] {
+		pc.add(field, "must be an ISO 3166-1 alpha-2 country code")
+	}
+}
+
+// timestamp reports a problem if the value is not an RFC 3339 timestamp.
+func (pc *payloadChecker) timestamp(field string, value *string) {
+	if value == nil {
+		return
+	}
+	if _, err := ParseTimestamp(value); err != nil {
+		pc.add(field, "must be an RFC 3339 timestamp")
+	}
+}
+
+// user reports the problems found in the [User].
+func (pc *payloadChecker) user(field string, user *User) {
+	if user == nil {
+		return
+	}
+	pc.required(field+".id", user.ID)
+	pc.timestamp(field+".createdAt", user.CreatedAt)
+	if user.Address != nil {
+		pc.countryCode(field+".address.countryCode", user.Address.CountryCode)
+	}
+}
+
+// session reports the problems found in the [Session].
+func (pc *payloadChecker) session(field string, session *Session) {
+	if session == nil {
+		return
+	}
+	pc.timestamp(field+".createdAt", session.CreatedAt)
+	pc.timestamp(field+".expiresAt", session.ExpiresAt)
+}
+
+// authentication reports the problems found in the [Authentication].
+func (pc *payloadChecker) authentication(field string, authentication *Authentication) {
+	if authentication == nil {
+		return
+	}
+	if authentication.Mode != nil {
+		checkOneOf(pc, field+".mode", *authentication.Mode, OtherAuthenticationMode, Biometric, Mail, MFA, OTP, Password)
+	}
+	if authentication.SocialProvider != nil {
+		checkOneOf(pc, field+".socialProvider", *authentication.SocialProvider, OtherAuthenticationSocialProvider,
+			Amazon, Apple, Facebook, Github, Google, Linkedin, Microsoft, Twitter, Yahoo)
+	}
+	if authentication.Type != nil {
+		checkOneOf(pc, field+".type", *authentication.Type, OtherAuthenticationType, Local, Social)
+	}
+}
+
+// monetaryValue reports the problems found in the [MonetaryValue].
+func (pc *payloadChecker) monetaryValue(field string, value *MonetaryValue) {
+	if value == nil {
+		return
+	}
+	if value.Amount < 0 {
+		pc.add(field+".amount", "must be a positive amount")
+	}
+	if !currencyRegexp.MatchString(value.Currency) {
+		pc.add(field+".currency", "must be an ISO 4217 currency code")
+	}
+}
+
+// checkOneOf reports a problem if the value is not one of the allowed values.
+func checkOneOf[T ~string](pc *payloadChecker, field string, value T, allowed ...T) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	pc.add(field, "must be one of %v", allowed)
+}
+
+// checkEvent returns the problems found in the event if [ClientWithStrictValidation] is used
+// and the event implements the [Checker] interface. It returns nil otherwise.
+func (c *Client) checkEvent(event Event) []ErrorInfo {
+	if !c.StrictValidation {
+		return nil
+	}
+	if checker, ok := event.(Checker); ok {
+		return checker.Check()
+	}
+	return nil
+}
+
+// newInvalidEventResponse returns the [ErrorResponsePayload] describing the problems found in the event.
+func newInvalidEventResponse(errors []ErrorInfo) ErrorResponsePayload {
+	message := "invalid event"
+	return ErrorResponsePayload{
+		Message: &message,
+		Errors:  errors,
+	}
+}
+
+// newInvalidEventValidateResponse returns the [ResponsePayload] describing the problems found in the event.
+// As for the other failures, the recommended action is [Allow].
+func newInvalidEventValidateResponse(errors []ErrorInfo) *ResponsePayload {
+	return &ResponsePayload{
+		SuccessResponsePayload: SuccessResponsePayload{
+			Action: Allow,
+			Status: Failure,
+		},
+		ErrorResponsePayload: newInvalidEventResponse(errors),
+	}
+}
@@ -0,0 +1,122 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	countryCode := "FRA"
+	createdAt := "2024-01-01 00:00:00"
+	expiresAt := "2024-01-01T01:00:00+02:00"
+	mode := AuthenticationMode("sms")
+	issuerCountryCode := "FR"
+
+	tests := []struct {
+		name  string
+		event Checker
+		want  []ErrorInfo
+	}{
+		{"Valid login", NewLoginEvent("test-account", Succeeded, LoginWithUser(User{ID: "123456"})), nil},
+		{"Empty account", NewLoginEvent("", Succeeded), []ErrorInfo{{Field: "account", Error: "must not be empty"}}},
+		{"Unknown status", NewLoginEvent("test-account", "unknown"), []ErrorInfo{{Field: "status", Error: "must be one of [failed succeeded]"}}},
+		{"Unknown authentication mode", NewLoginEvent("test-account", Failed, LoginWithAuthentication(Authentication{Mode: &mode})), []ErrorInfo{
+			{Field: "authentication.mode", Error: "must be one of [other biometric mail mfa otp password]"},
+		}},
+		{"Missing user ID on registration", NewRegistrationEvent("test-account", User{}), []ErrorInfo{{Field: "user.id", Error: "must not be empty"}}},
+		{"Invalid country code", NewAccountUpdateEvent("test-account", AccountUpdateWithUser(User{ID: "123456", Address: &Address{CountryCode: &countryCode}})), []ErrorInfo{
+			{Field: "user.address.countryCode", Error: "must be an ISO 3166-1 alpha-2 country code"},
+		}},
+		{"Invalid timestamps", NewPasswordUpdateEvent("test-account", User{ID: "123456", CreatedAt: &createdAt}, UserUpdate, PasswordUpdateSucceeded, PasswordUpdateWithSession(Session{CreatedAt: &createdAt, ExpiresAt: &expiresAt})), []ErrorInfo{
+			{Field: "user.createdAt", Error: "must be an RFC 3339 timestamp"},
+			{Field: "session.createdAt", Error: "must be an RFC 3339 timestamp"},
+		}},
+		{"Valid payment method", NewPaymentMethodEvent("test-account", PaymentMethodAdd, Card, PaymentMethodWithIssuerCountryCode(issuerCountryCode)), nil},
+		{"Invalid monetary value", NewPromoCodeEvent("test-account", "PROMO", PromoCodeWithValue(MonetaryValue{Amount: -1, Currency: "eur"})), []ErrorInfo{
+			{Field: "value.amount", Error: "must be a positive amount"},
+			{Field: "value.currency", Error: "must be an ISO 4217 currency code"},
+		}},
+		{"Missing referrer", NewReferralEvent("test-account", "CODE", ""), []ErrorInfo{{Field: "referrerAccount", Error: "must not be empty"}}},
+		{"Negative points", NewLoyaltyEvent("test-account", LoyaltyRedemption, -10), []ErrorInfo{{Field: "points", Error: "must be a positive integer"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.event.Check())
+		})
+	}
+}
+
+func TestPayloadChecker_CountryCode(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"FR", true},
+		{"AQ", true},
+		{"UM", true},
+		{"XK", true},
+		{"fr", false},
+		{"ZZ", false},
+		{"EU", false},
+		{"", false},
+	}
+
+	for _, tc := range tests {
+		var pc payloadChecker
+		pc.countryCode("countryCode", &tc.value)
+		assert.Equal(t, tc.valid, pc.result() == nil, tc.value)
+	}
+	assert.Len(t, countryCodes, 250)
+}
+
+func TestWithStrictValidation(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"action":"allow"}`))
+	}))
+	defer server.Close()
+
+	t.Run("Invalid events are not sent", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL), ClientWithStrictValidation())
+		assert.Nil(t, err)
+
+		resp, err := c.Validate(setupRequest(), NewRegistrationEvent("", User{}))
+		assert.ErrorIs(t, err, ErrInvalidEvent)
+		assert.Equal(t, Allow, resp.Action)
+		assert.Equal(t, Failure, resp.Status)
+		assert.Len(t, resp.Errors, 2)
+
+		errResp, err := c.Collect(setupRequest(), NewLoginEvent("", Succeeded))
+		assert.ErrorIs(t, err, ErrInvalidEvent)
+		assert.Equal(t, []ErrorInfo{{Field: "account", Error: "must not be empty"}}, errResp.Errors)
+		assert.Equal(t, 0, calls)
+
+		_, err = c.Validate(setupRequest(), NewLoginEvent("test-account", Succeeded))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Invalid events are sent without strict validation", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+		assert.Nil(t, err)
+
+		_, err = c.Validate(setupRequest(), NewRegistrationEvent("", User{}))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func ExampleLoginEvent_Check() {
+	event := NewLoginEvent("", Succeeded)
+
+	for _, problem := range event.Check() {
+		fmt.Println(problem.Field, problem.Error)
+	}
+	// Output: account must not be empty
+}
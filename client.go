@@ -3,12 +3,15 @@ package fraudsdkgo
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/netip"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -30,16 +33,171 @@ func ClientWithTimeout(timeout int) ClientOption {
 	}
 }
 
+// ClientWithTrustedProxies is a functional option to set the IP addresses or CIDR ranges of the proxies
+// (e.g. load balancers) placed in front of the application.
+// When set, the IP of the emitter is resolved by walking the `X-Forwarded-For` header from right to left,
+// skipping the trusted proxies, instead of using the RemoteAddr field of the request.
+func ClientWithTrustedProxies(cidrs []string) ClientOption {
+	return func(c *Client) {
+		c.TrustedProxies = cidrs
+	}
+}
+
+// ClientWithTrustedHops is a functional option to set the number of proxies placed in front of the application.
+// When set, the IP of the emitter is the one added to the `X-Forwarded-For` header by the outermost proxy.
+// It cannot be used together with [ClientWithTrustedProxies].
+func ClientWithTrustedHops(hops int) ClientOption {
+	return func(c *Client) {
+		c.TrustedHops = hops
+	}
+}
+
+// ClientWithForwardedHeader is a functional option to read the forwarding chain from the RFC 7239 `Forwarded`
+// header set by the trusted proxies, instead of the `X-Forwarded-For` header which is then ignored.
+// The IP, the host and the protocol are only read from the elements appended by the trusted proxies.
+// It has no effect without [ClientWithTrustedProxies] or [ClientWithTrustedHops].
+func ClientWithForwardedHeader() ClientOption {
+	return func(c *Client) {
+		c.ForwardedHeader = true
+	}
+}
+
+// ClientWithMissingIPPolicy is a functional option to set the behavior of the [Client] when the IP
+// of the emitter cannot be found in the incoming request. The default policy is [MissingIPFail].
+func ClientWithMissingIPPolicy(policy MissingIPPolicy) ClientOption {
+	return func(c *Client) {
+		c.MissingIPPolicy = policy
+	}
+}
+
+// ClientWithFallbackIP is a functional option to set the IP sent when the IP of the emitter
+// cannot be found in the incoming request. It sets the [MissingIPFallback] policy.
+func ClientWithFallbackIP(ip string) ClientOption {
+	return func(c *Client) {
+		c.FallbackIP = ip
+		c.MissingIPPolicy = MissingIPFallback
+	}
+}
+
+// ClientWithAccessLists is a functional option to set the [AccessLists] consulted before sending
+// the events to the Account Protect API. They can be replaced at runtime with [Client.SetAccessLists].
+func ClientWithAccessLists(lists AccessLists) ClientOption {
+	return func(c *Client) {
+		c.AccessLists = &lists
+	}
+}
+
+// ClientWithAccessListsFile is a functional option to read the [AccessLists] from the JSON file
+// (see [LoadAccessLists]). It takes precedence over [ClientWithAccessLists].
+// The file can be reloaded at runtime with [Client.ReloadAccessLists] or [Client.WatchAccessLists].
+func ClientWithAccessListsFile(path string) ClientOption {
+	return func(c *Client) {
+		c.AccessListsFile = path
+	}
+}
+
+// ClientWithMinimizationProfile is a functional option to set the [MinimizationProfile] applied to the
+// request payloads before sending them to the Account Protect API.
+// It can be overridden for a call with [ContextWithMinimizationProfile].
+func ClientWithMinimizationProfile(profile MinimizationProfile) ClientOption {
+	return func(c *Client) {
+		c.MinimizationProfile = &profile
+	}
+}
+
+// ClientWithPolicy is a functional option to set the [Policy] adjusting the recommendation of
+// the Account Protect API (see [LoadPolicy]).
+func ClientWithPolicy(policy Policy) ClientOption {
+	return func(c *Client) {
+		c.Policy = &policy
+	}
+}
+
+// ClientWithStrictValidation is a functional option to check the events before sending them to
+// the Account Protect API (see [Checker]).
+// The events reporting problems are not sent: an error wrapping [ErrInvalidEvent] is returned,
+// with an [ErrorResponsePayload] describing the problems.
+func ClientWithStrictValidation() ClientOption {
+	return func(c *Client) {
+		c.StrictValidation = true
+	}
+}
+
+// ClientWithPseudonymization is a functional option to pseudonymize the personal information of the [User]
+// before sending it to the Account Protect API.
+// The Email, the Phone, the NormalizedEmail, the NormalizedPhone, the FirstName, the LastName and the Name,
+// Line1 and Line2 fields of the [Address] are normalized and replaced by their HMAC-SHA256 keyed with the given secret,
+// as well as the previous and new values of the [ContactUpdateEvent].
+// The same secret must be used across the applications to keep the values consistent.
+func ClientWithPseudonymization(key []byte) ClientOption {
+	return func(c *Client) {
+		c.PseudonymizationKey = key
+	}
+}
+
+// ClientWithCDN is a functional option to set the [CDN] placed in front of the application.
+// When set, the IP, the protocol and the port of the emitter are read from the headers of the CDN.
+// If trusted proxies are configured, these headers are only used when the RemoteAddr is trusted.
+// Otherwise, they are read from any request: a client reaching the application without going through the CDN
+// can set them, so [ClientWithTrustedProxies] should be used with the ranges of the CDN.
+//
+// Use [CDN.WithGeolocation] to also send the geolocation headers of the CDN.
+func ClientWithCDN(cdn CDN) ClientOption {
+	return func(c *Client) {
+		c.CDN = &cdn
+	}
+}
+
+// ClientWithClientIDSources is a functional option to set the ordered list of [ClientIDSource] used to read
+// the ClientID from the incoming request. The first source supplying a non-empty value is used.
+// The [DefaultClientIDSources] are used if no source is specified.
+func ClientWithClientIDSources(sources ...ClientIDSource) ClientOption {
+	return func(c *Client) {
+		c.ClientIDSources = sources
+	}
+}
+
+// ClientWithHeaderFingerprint is a functional option to send the order of the header names and
+// a hash of the set of the header names of the incoming request.
+// The order is only available when the server uses a listener created with [NewHeaderOrderListener].
+func ClientWithHeaderFingerprint() ClientOption {
+	return func(c *Client) {
+		c.HeaderFingerprint = true
+	}
+}
+
+// ClientWithTruncationSizes is a functional option to override the maximal size of the given fields of the [Header].
+// A positive size keeps the beginning of the value, a negative size keeps its end, and a size of 0 disables the truncation.
+func ClientWithTruncationSizes(sizes map[ApiFields]int) ClientOption {
+	return func(c *Client) {
+		if c.TruncationSizes == nil {
+			c.TruncationSizes = make(map[ApiFields]int, len(sizes))
+		}
+		for key, size := range sizes {
+			c.TruncationSizes[key] = size
+		}
+	}
+}
+
+// ClientWithTruncationHook is a functional option to set the function called with the list of the fields
+// of the [Header] that have been truncated, e.g. to log them or to increment a metric.
+func ClientWithTruncationHook(hook TruncationHook) ClientOption {
+	return func(c *Client) {
+		c.TruncationHook = hook
+	}
+}
+
 // NewClient instantiates a new DataDome [Client] to perform calls to the Account Protect API.
 // The fields may be customized through [ClientOption] functions.
 // It returns an error in case of bad inputs in the options.
 func NewClient(fraudApiKey string, options ...ClientOption) (*Client, error) {
 	c := &Client{
-		Endpoint:      DefaultEndpointValue,
-		FraudAPIKey:   fraudApiKey,
-		Timeout:       DefaultTimeoutValue,
-		moduleName:    defaultModuleNameValue,
-		moduleVersion: defaultModuleVersionValue,
+		Endpoint:        DefaultEndpointValue,
+		FraudAPIKey:     fraudApiKey,
+		MissingIPPolicy: MissingIPFail,
+		Timeout:         DefaultTimeoutValue,
+		moduleName:      defaultModuleNameValue,
+		moduleVersion:   defaultModuleVersionValue,
 	}
 
 	// apply functional options
@@ -54,6 +212,56 @@ func NewClient(fraudApiKey string, options ...ClientOption) (*Client, error) {
 	if c.Timeout <= 0 {
 		return nil, ErrWrongTimeoutValue
 	}
+	for _, source := range c.ClientIDSources {
+		if source.extract == nil {
+			return nil, ErrWrongClientIDSourcesValue
+		}
+	}
+	switch c.MissingIPPolicy {
+	case MissingIPFail, MissingIPOmit:
+	case MissingIPFallback:
+		fallbackIP, ok := parseIP(c.FallbackIP)
+		if !ok {
+			return nil, ErrWrongFallbackIPValue
+		}
+		c.FallbackIP = fallbackIP.String()
+	default:
+		return nil, ErrWrongMissingIPPolicyValue
+	}
+	c.accessLists = &accessListsStore{}
+	if c.AccessListsFile != "" {
+		if err := c.accessLists.load(c.AccessListsFile); err != nil {
+			return nil, err
+		}
+	} else if c.AccessLists != nil {
+		if err := c.accessLists.set(c.AccessLists, [sha256.Size]byte{}); err != nil {
+			return nil, err
+		}
+	}
+	if c.MinimizationProfile != nil {
+		if err := c.MinimizationProfile.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if c.Policy != nil {
+		if err := c.Policy.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if c.PseudonymizationKey != nil && len(c.PseudonymizationKey) == 0 {
+		return nil, ErrWrongPseudonymizationKeyValue
+	}
+	if c.TrustedHops < 0 {
+		return nil, ErrWrongTrustedHopsValue
+	}
+	if c.TrustedHops > 0 && len(c.TrustedProxies) > 0 {
+		return nil, ErrTrustedProxiesConflict
+	}
+	trustedNetworks, err := parseTrustedProxies(c.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	c.trustedNetworks = trustedNetworks
 
 	// set not exported values
 	c.httpClient = &http.Client{
@@ -80,27 +288,79 @@ func (c *Client) getModule() *Module {
 // It constructs this payload by reading the [RequestMetadata] fields if specified.
 // It will extracts the information from the incoming request otherwise.
 //
-// An error may be returned if the IP cannot be retrieved.
+// The IP, the protocol, the host and the port are read from the hop of the forwarding chain
+// describing the emitter (see [ClientWithTrustedProxies] and [ClientWithTrustedHops]) when available.
+// The JA3 and JA4 fingerprints are read from the context of the request when a [TLSFingerprinter] is used.
+// The header order and the hash of the header set are only extracted when [ClientWithHeaderFingerprint] is used.
+//
+// The IPs are normalized to their canonical form (see [parseIP]).
+// When the IP cannot be retrieved, the [MissingIPPolicy] of the [Client] applies: an error wrapping
+// [ErrIPNotFound] is returned with the [MissingIPFail] policy.
 func (c *Client) buildHeader(r *http.Request, rm *RequestMetadata) (*Header, error) {
+	t := c.newTruncator()
+	hop, hopErr := resolveClientHop(r, c.trustedNetworks, c.TrustedHops, c.ForwardedHeader)
+	cdn := c.getTrustedCDN(r)
+
 	var proto string
 	if rm.Protocol != nil {
 		proto = *rm.Protocol
+	} else if cdnProto := cdn.getProtocol(r); cdnProto != "" {
+		proto = cdnProto
+	} else if hop.proto == "http" || hop.proto == "https" {
+		proto = hop.proto
 	} else {
 		proto = getProtocol(r)
 	}
 
-	var ip string
+	var ip, resolvedIP string
+	if hopErr == nil {
+		resolvedIP = hop.node
+	}
 	if rm.Addr != nil {
-		ip = *rm.Addr
+		ip = normalizeIP(*rm.Addr)
+		resolvedIP = ip
+	} else if cdnIP := cdn.getClientIP(r); cdnIP != "" {
+		ip = cdnIP
+		if len(c.trustedNetworks) > 0 {
+			// the headers of the CDN are only used when the RemoteAddr is trusted
+			resolvedIP = cdnIP
+		}
+	} else if hopErr == nil {
+		ip = hop.node
 	} else {
-		userIp, err := getIP(r)
-		if err != nil {
-			return nil, fmt.Errorf("fail to parse request's IP: %w", err)
+		switch c.MissingIPPolicy {
+		case MissingIPFallback:
+			ip = c.FallbackIP
+		case MissingIPOmit:
+			ip = ""
+		default:
+			return nil, fmt.Errorf("fail to parse request's IP: %w", hopErr)
 		}
-		ip = userIp
 	}
 
-	port := useMetadata(getPort(r), rm.Port)
+	host := r.Host
+	port := getPort(r)
+	if hop.host != "" {
+		host = hop.host
+		port = getHostPort(hop.host)
+	}
+	if cdnPort := cdn.getPort(r); cdnPort != -1 {
+		port = cdnPort
+	}
+
+	clientID, _, _ := c.ResolveClientID(r)
+
+	var headerOrder, headerSetHash string
+	if c.HeaderFingerprint {
+		headerOrder = strings.Join(getHeaderOrder(r), ",")
+		headerSetHash = getHeaderSetHash(r.Header)
+	}
+
+	tlsFingerprint := TLSFingerprintFromContext(r.Context())
+	if tlsFingerprint == nil {
+		tlsFingerprint = &TLSFingerprint{}
+	}
+	port = useMetadata(port, rm.Port)
 	if port == -1 {
 		if proto == "https" {
 			port = 443
@@ -109,46 +369,183 @@ func (c *Client) buildHeader(r *http.Request, rm *RequestMetadata) (*Header, err
 		}
 	}
 
-	return &Header{
-		Accept:                 truncateValue(Accept, useMetadata(r.Header.Get("accept"), rm.Accept)),
-		AcceptCharset:          truncateValue(AcceptCharset, useMetadata(r.Header.Get("accept-charset"), rm.AcceptCharset)),
-		AcceptEncoding:         truncateValue(AcceptEncoding, useMetadata(r.Header.Get("accept-encoding"), rm.AcceptEncoding)),
-		AcceptLanguage:         truncateValue(AcceptLanguage, useMetadata(r.Header.Get("accept-language"), rm.AcceptLanguage)),
+	header := &Header{
+		Accept:                 t.value(Accept, useMetadata(r.Header.Get("accept"), rm.Accept)),
+		AcceptCharset:          t.value(AcceptCharset, useMetadata(r.Header.Get("accept-charset"), rm.AcceptCharset)),
+		AcceptEncoding:         t.value(AcceptEncoding, useMetadata(r.Header.Get("accept-encoding"), rm.AcceptEncoding)),
+		AcceptLanguage:         t.value(AcceptLanguage, useMetadata(r.Header.Get("accept-language"), rm.AcceptLanguage)),
 		Addr:                   ip,
-		ClientID:               truncateValue(ClientID, useMetadata(getClientId(r), rm.ClientID)),
-		Connection:             truncateValue(Connection, useMetadata(r.Header.Get("connection"), rm.Connection)),
-		ContentType:            truncateValue(ContentType, useMetadata(r.Header.Get("content-type"), rm.ContentType)),
-		From:                   truncateValue(From, useMetadata(r.Header.Get("from"), rm.From)),
-		Host:                   truncateValue(Host, useMetadata(r.Host, rm.Host)),
-		Method:                 r.Method,
-		Referer:                truncateValue(Referer, useMetadata(r.Header.Get("referer"), rm.Referer)),
-		Request:                truncateValue(Request, useMetadata(getURL(r), rm.Request)),
-		Origin:                 truncateValue(Origin, useMetadata(r.Header.Get("origin"), rm.Origin)),
+		CDNLocation:            cdn.getLocation(r, t),
+		ClientID:               t.value(ClientID, useMetadata(clientID, rm.ClientID)),
+		Connection:             t.value(Connection, useMetadata(r.Header.Get("connection"), rm.Connection)),
+		ContentType:            t.value(ContentType, useMetadata(r.Header.Get("content-type"), rm.ContentType)),
+		From:                   t.value(From, useMetadata(r.Header.Get("from"), rm.From)),
+		HeaderOrder:            t.pointerValue(HeaderOrder, useMetadata(headerOrder, rm.HeaderOrder)),
+		HeaderSetHash:          t.pointerValue(HeaderSetHash, useMetadata(headerSetHash, rm.HeaderSetHash)),
+		Host:                   t.value(Host, useMetadata(host, rm.Host)),
+		JA3:                    t.pointerValue(JA3, useMetadata(tlsFingerprint.JA3, rm.JA3)),
+		JA4:                    t.pointerValue(JA4, useMetadata(tlsFingerprint.JA4, rm.JA4)),
+		Method:                 useMetadata(r.Method, rm.Method),
+		Referer:                t.value(Referer, useMetadata(r.Header.Get("referer"), rm.Referer)),
+		Request:                t.value(Request, useMetadata(getURL(r), rm.Request)),
+		Origin:                 t.value(Origin, useMetadata(r.Header.Get("origin"), rm.Origin)),
+		Port:                   port,
+		Protocol:               proto,
+		SecCHUA:                t.pointerValue(SecCHUA, useMetadata(r.Header.Get("sec-ch-ua"), rm.SecCHUA)),
+		SecCHUAMobile:          t.pointerValue(SecCHUAMobile, useMetadata(r.Header.Get("sec-ch-ua-mobile"), rm.SecCHUAMobile)),
+		SecCHUAPlatform:        t.pointerValue(SecCHUAPlatform, useMetadata(r.Header.Get("sec-ch-ua-platform"), rm.SecCHUAPlatform)),
+		SecCHUAArch:            t.pointerValue(SecCHUAArch, useMetadata(r.Header.Get("sec-ch-ua-arch"), rm.SecCHUAArch)),
+		SecCHUAFullVersionList: t.pointerValue(SecCHUAFullVersionList, useMetadata(r.Header.Get("sec-ch-ua-full-version-list"), rm.SecCHUAFullVersionList)),
+		SecCHUAModel:           t.pointerValue(SecCHUAModel, useMetadata(r.Header.Get("sec-ch-ua-model"), rm.SecCHUAModel)),
+		SecCHDeviceMemory:      t.pointerValue(SecCHDeviceMemory, useMetadata(r.Header.Get("sec-ch-device-memory"), rm.SecCHDeviceMemory)),
+		SecCHUABitness:         t.pointerValue(SecCHUABitness, useMetadata(r.Header.Get("sec-ch-ua-bitness"), rm.SecCHUABitness)),
+		SecCHUAFormFactors:     t.pointerValue(SecCHUAFormFactors, useMetadata(r.Header.Get("sec-ch-ua-form-factors"), rm.SecCHUAFormFactors)),
+		SecCHUAPlatformVersion: t.pointerValue(SecCHUAPlatformVersion, useMetadata(r.Header.Get("sec-ch-ua-platform-version"), rm.SecCHUAPlatformVersion)),
+		SecCHUAWoW64:           t.pointerValue(SecCHUAWoW64, useMetadata(r.Header.Get("sec-ch-ua-wow64"), rm.SecCHUAWoW64)),
+		SecFetchDest:           t.pointerValue(SecFetchDest, useMetadata(r.Header.Get("sec-fetch-dest"), rm.SecFetchDest)),
+		SecFetchMode:           t.pointerValue(SecFetchMode, useMetadata(r.Header.Get("sec-fetch-mode"), rm.SecFetchMode)),
+		SecFetchSite:           t.pointerValue(SecFetchSite, useMetadata(r.Header.Get("sec-fetch-site"), rm.SecFetchSite)),
+		SecFetchUser:           t.pointerValue(SecFetchUser, useMetadata(r.Header.Get("sec-fetch-user"), rm.SecFetchUser)),
+		SaveData:               t.pointerValue(SaveData, useMetadata(r.Header.Get("save-data"), rm.SaveData)),
+		DNT:                    t.pointerValue(DNT, useMetadata(r.Header.Get("dnt"), rm.DNT)),
+		Priority:               t.pointerValue(Priority, useMetadata(r.Header.Get("priority"), rm.Priority)),
+		ServerHostname:         t.value(ServerHostname, useMetadata(r.Host, rm.ServerHostname)),
+		UserAgent:              t.value(UserAgent, useMetadata(r.Header.Get("user-agent"), rm.UserAgent)),
+		XForwardedForIP:        t.value(XForwardedForIP, useMetadata(r.Header.Get("x-forwarded-for"), rm.XForwardedForIP)),
+		XRealIP:                t.value(XRealIP, useMetadata(r.Header.Get("x-real-ip"), rm.XRealIP)),
+	}
+	c.reportTruncation(r.Context(), t)
+
+	header.resolvedAddr = resolvedIP
+	return header, nil
+}
+
+// buildHeaderFromMetadata is used to construct the [Header] type from the [RequestMetadata] only,
+// when no incoming request is available.
+// The unspecified fields are left empty, the protocol defaults to `http` and the port is deduced from the protocol.
+//
+// An error is returned if the Addr or the Method fields are not specified.
+func (c *Client) buildHeaderFromMetadata(ctx context.Context, rm *RequestMetadata) (*Header, error) {
+	t := c.newTruncator()
+	if rm == nil || rm.Addr == nil || *rm.Addr == "" {
+		return nil, ErrAddrMissing
+	}
+	if _, err := netip.ParseAddr(strings.TrimSpace(*rm.Addr)); err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrWrongAddrValue, *rm.Addr)
+	}
+	if rm.Method == nil || *rm.Method == "" {
+		return nil, ErrMethodMissing
+	}
+
+	proto := useMetadata("http", rm.Protocol)
+	port := useMetadata(-1, rm.Port)
+	if port == -1 {
+		if proto == "https" {
+			port = 443
+		} else {
+			port = 80
+		}
+	}
+
+	header := &Header{
+		Accept:                 t.value(Accept, useMetadata("", rm.Accept)),
+		AcceptCharset:          t.value(AcceptCharset, useMetadata("", rm.AcceptCharset)),
+		AcceptEncoding:         t.value(AcceptEncoding, useMetadata("", rm.AcceptEncoding)),
+		AcceptLanguage:         t.value(AcceptLanguage, useMetadata("", rm.AcceptLanguage)),
+		Addr:                   normalizeIP(*rm.Addr),
+		ClientID:               t.value(ClientID, useMetadata("", rm.ClientID)),
+		Connection:             t.value(Connection, useMetadata("", rm.Connection)),
+		ContentType:            t.value(ContentType, useMetadata("", rm.ContentType)),
+		From:                   t.value(From, useMetadata("", rm.From)),
+		HeaderOrder:            t.pointerValue(HeaderOrder, useMetadata("", rm.HeaderOrder)),
+		HeaderSetHash:          t.pointerValue(HeaderSetHash, useMetadata("", rm.HeaderSetHash)),
+		Host:                   t.value(Host, useMetadata("", rm.Host)),
+		JA3:                    t.pointerValue(JA3, useMetadata("", rm.JA3)),
+		JA4:                    t.pointerValue(JA4, useMetadata("", rm.JA4)),
+		Method:                 *rm.Method,
+		Referer:                t.value(Referer, useMetadata("", rm.Referer)),
+		Request:                t.value(Request, useMetadata("", rm.Request)),
+		Origin:                 t.value(Origin, useMetadata("", rm.Origin)),
 		Port:                   port,
 		Protocol:               proto,
-		SecCHUA:                truncatePointerValue(SecCHUA, useMetadata(r.Header.Get("sec-ch-ua"), rm.SecCHUA)),
-		SecCHUAMobile:          truncatePointerValue(SecCHUAMobile, useMetadata(r.Header.Get("sec-ch-ua-mobile"), rm.SecCHUAMobile)),
-		SecCHUAPlatform:        truncatePointerValue(SecCHUAPlatform, useMetadata(r.Header.Get("sec-ch-ua-platform"), rm.SecCHUAPlatform)),
-		SecCHUAArch:            truncatePointerValue(SecCHUAArch, useMetadata(r.Header.Get("sec-ch-ua-arch"), rm.SecCHUAArch)),
-		SecCHUAFullVersionList: truncatePointerValue(SecCHUAFullVersionList, useMetadata(r.Header.Get("sec-ch-ua-full-version-list"), rm.SecCHUAFullVersionList)),
-		SecCHUAModel:           truncatePointerValue(SecCHUAModel, useMetadata(r.Header.Get("sec-ch-ua-model"), rm.SecCHUAModel)),
-		SecCHDeviceMemory:      truncatePointerValue(SecCHDeviceMemory, useMetadata(r.Header.Get("sec-ch-device-memory"), rm.SecCHDeviceMemory)),
-		ServerHostname:         truncateValue(ServerHostname, useMetadata(r.Host, rm.ServerHostname)),
-		UserAgent:              truncateValue(UserAgent, useMetadata(r.Header.Get("user-agent"), rm.UserAgent)),
-		XForwardedForIP:        truncateValue(XForwardedForIP, useMetadata(r.Header.Get("x-forwarded-for"), rm.XForwardedForIP)),
-		XRealIP:                truncateValue(XRealIP, useMetadata(r.Header.Get("x-real-ip"), rm.XRealIP)),
-	}, nil
+		SecCHUA:                t.pointerValue(SecCHUA, useMetadata("", rm.SecCHUA)),
+		SecCHUAMobile:          t.pointerValue(SecCHUAMobile, useMetadata("", rm.SecCHUAMobile)),
+		SecCHUAPlatform:        t.pointerValue(SecCHUAPlatform, useMetadata("", rm.SecCHUAPlatform)),
+		SecCHUAArch:            t.pointerValue(SecCHUAArch, useMetadata("", rm.SecCHUAArch)),
+		SecCHUAFullVersionList: t.pointerValue(SecCHUAFullVersionList, useMetadata("", rm.SecCHUAFullVersionList)),
+		SecCHUAModel:           t.pointerValue(SecCHUAModel, useMetadata("", rm.SecCHUAModel)),
+		SecCHDeviceMemory:      t.pointerValue(SecCHDeviceMemory, useMetadata("", rm.SecCHDeviceMemory)),
+		SecCHUABitness:         t.pointerValue(SecCHUABitness, useMetadata("", rm.SecCHUABitness)),
+		SecCHUAFormFactors:     t.pointerValue(SecCHUAFormFactors, useMetadata("", rm.SecCHUAFormFactors)),
+		SecCHUAPlatformVersion: t.pointerValue(SecCHUAPlatformVersion, useMetadata("", rm.SecCHUAPlatformVersion)),
+		SecCHUAWoW64:           t.pointerValue(SecCHUAWoW64, useMetadata("", rm.SecCHUAWoW64)),
+		SecFetchDest:           t.pointerValue(SecFetchDest, useMetadata("", rm.SecFetchDest)),
+		SecFetchMode:           t.pointerValue(SecFetchMode, useMetadata("", rm.SecFetchMode)),
+		SecFetchSite:           t.pointerValue(SecFetchSite, useMetadata("", rm.SecFetchSite)),
+		SecFetchUser:           t.pointerValue(SecFetchUser, useMetadata("", rm.SecFetchUser)),
+		SaveData:               t.pointerValue(SaveData, useMetadata("", rm.SaveData)),
+		DNT:                    t.pointerValue(DNT, useMetadata("", rm.DNT)),
+		Priority:               t.pointerValue(Priority, useMetadata("", rm.Priority)),
+		ServerHostname:         t.value(ServerHostname, useMetadata("", rm.ServerHostname)),
+		UserAgent:              t.value(UserAgent, useMetadata("", rm.UserAgent)),
+		XForwardedForIP:        t.value(XForwardedForIP, useMetadata("", rm.XForwardedForIP)),
+		XRealIP:                t.value(XRealIP, useMetadata("", rm.XRealIP)),
+	}
+	c.reportTruncation(ctx, t)
+
+	header.resolvedAddr = header.Addr
+	return header, nil
+}
+
+// newMetadataRequest returns the request passed to the [Event] when no incoming request is available.
+// It only carries the context and the method of the original call.
+func newMetadataRequest(ctx context.Context, header *Header) *http.Request {
+	r := &http.Request{
+		Method: header.Method,
+		URL:    &url.URL{},
+		Header: http.Header{},
+	}
+	return r.WithContext(ctx)
+}
+
+// newTruncator returns a [truncator] using the truncation sizes overridden on the [Client].
+func (c *Client) newTruncator() *truncator {
+	return &truncator{sizes: c.TruncationSizes}
+}
+
+// reportTruncation calls the [TruncationHook] of the [Client] if fields have been truncated.
+func (c *Client) reportTruncation(ctx context.Context, t *truncator) {
+	if c.TruncationHook != nil && len(t.truncated) > 0 {
+		c.TruncationHook(ctx, t.truncated)
+	}
+}
+
+// getTrustedCDN returns the [CDN] configured on the [Client] if its headers can be trusted for the request,
+// i.e. if no trusted proxies are configured or if the RemoteAddr is trusted.
+// It returns an empty [CDN] otherwise.
+func (c *Client) getTrustedCDN(r *http.Request) *CDN {
+	if c.CDN == nil {
+		return &CDN{}
+	}
+	if len(c.trustedNetworks) > 0 {
+		remoteIP, ok := parseIP(r.RemoteAddr)
+		if !ok || !isTrustedIP(remoteIP, c.trustedNetworks) {
+			return &CDN{}
+		}
+	}
+	return c.CDN
 }
 
 // validate is the internal function that performs the validation request to the Account Protect API.
 func (c *Client) validate(r *http.Request, event Event, requestMetadata *RequestMetadata) (*ResponsePayload, error) {
+	if problems := c.checkEvent(event); len(problems) > 0 {
+		return newInvalidEventValidateResponse(problems), ErrInvalidEvent
+	}
 	header, err := c.buildHeader(r, requestMetadata)
 	if err != nil {
 		return nil, fmt.Errorf("fail to extract request fingerprint: %w", err)
 	}
-	module := c.getModule()
-
-	return event.Validate(c, r, module, header)
+	return c.validateEvent(r, event, header)
 }
 
 // Validate performs a validation request to the DataDome's Account Protect API.
@@ -170,15 +567,68 @@ func (c *Client) ValidateWithRequestMetadata(r *http.Request, event Event, reque
 	return c.validate(r, event, requestMetadata)
 }
 
+// ValidateFromMetadata performs a validation request to the DataDome's Account Protect API
+// without any incoming request, e.g. from background jobs, message consumers or gRPC services.
+// The [Header] is built from the [RequestMetadata] only, and the provided context is used for the request
+// to the Account Protect API.
+//
+// The Addr and Method fields of the [RequestMetadata] are required: [ErrAddrMissing] or [ErrMethodMissing]
+// is returned otherwise. The Addr must be an IP address without port: an error wrapping [ErrWrongAddrValue]
+// is returned otherwise.
+func (c *Client) ValidateFromMetadata(ctx context.Context, event Event, requestMetadata *RequestMetadata) (*ResponsePayload, error) {
+	if problems := c.checkEvent(event); len(problems) > 0 {
+		return newInvalidEventValidateResponse(problems), ErrInvalidEvent
+	}
+	header, err := c.buildHeaderFromMetadata(ctx, requestMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("fail to extract request fingerprint: %w", err)
+	}
+	return c.validateEvent(newMetadataRequest(ctx, header), event, header)
+}
+
+// validateEvent performs the validation request of the event and applies the [Policy] of the [Client]
+// to the successful responses. The events matching the [AccessLists] of the [Client] are not sent.
+func (c *Client) validateEvent(r *http.Request, event Event, header *Header) (*ResponsePayload, error) {
+	account, action := describeEvent(event)
+	if resp := c.matchAccessLists(account, header); resp != nil {
+		return resp, nil
+	}
+	resp, err := event.Validate(c, r, c.getModule(), header)
+	if err == nil && resp != nil && resp.Status == OK {
+		c.applyPolicy(resp, action, account, header)
+	}
+	return resp, err
+}
+
+// collectEvent performs the enrichment request of the event.
+// The events matching the [AccessLists] of the [Client] are not sent.
+func (c *Client) collectEvent(r *http.Request, event Event, header *Header) (*ErrorResponsePayload, error) {
+	account, _ := describeEvent(event)
+	if c.matchAccessLists(account, header) != nil {
+		return nil, nil
+	}
+	return event.Collect(c, r, c.getModule(), header)
+}
+
+// describeEvent returns the account and the type of the event if it implements the [Describer] interface.
+func describeEvent(event Event) (string, Action) {
+	if describer, ok := event.(Describer); ok {
+		return describer.Describe()
+	}
+	return "", ""
+}
+
 // collect is the internal function that performs the enrichment request to the Account Protect API.
 func (c *Client) collect(r *http.Request, event Event, requestMetadata *RequestMetadata) (*ErrorResponsePayload, error) {
+	if problems := c.checkEvent(event); len(problems) > 0 {
+		response := newInvalidEventResponse(problems)
+		return &response, ErrInvalidEvent
+	}
 	header, err := c.buildHeader(r, requestMetadata)
 	if err != nil {
 		return nil, fmt.Errorf("fail to extract request fingerprint: %w", err)
 	}
-	module := c.getModule()
-
-	return event.Collect(c, r, module, header)
+	return c.collectEvent(r, event, header)
 }
 
 // Collect performs an enrichment request to the DataDome's Account Protect API.
@@ -199,22 +649,50 @@ func (c *Client) CollectWithRequestMetadata(r *http.Request, event Event, reques
 	return c.collect(r, event, requestMetadata)
 }
 
+// CollectFromMetadata performs an enrichment request to the DataDome's Account Protect API
+// without any incoming request, e.g. from background jobs, message consumers or gRPC services.
+// The [Header] is built from the [RequestMetadata] only, and the provided context is used for the request
+// to the Account Protect API.
+//
+// The Addr and Method fields of the [RequestMetadata] are required: [ErrAddrMissing] or [ErrMethodMissing]
+// is returned otherwise. The Addr must be an IP address without port: an error wrapping [ErrWrongAddrValue]
+// is returned otherwise.
+func (c *Client) CollectFromMetadata(ctx context.Context, event Event, requestMetadata *RequestMetadata) (*ErrorResponsePayload, error) {
+	if problems := c.checkEvent(event); len(problems) > 0 {
+		response := newInvalidEventResponse(problems)
+		return &response, ErrInvalidEvent
+	}
+	header, err := c.buildHeaderFromMetadata(ctx, requestMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("fail to extract request fingerprint: %w", err)
+	}
+	return c.collectEvent(newMetadataRequest(ctx, header), event, header)
+}
+
 // performRequest performs the appropriate request to the DataDome's Account Protect API.
 // This functions will:
-// 1. Encode the provided payload that implements the [AllowedRequestPayload] interface.
-// 2. Construct the request (i.e. attach the body, set the appropriate headers)
-// 3. Performs the request to the Account Protect API.
-// 4. Returns the response status code, the response body, and the potential error.
+// 1. Pseudonymize the [User] and the contact points of the payload if [ClientWithPseudonymization] is used, then encode the provided payload that implements the [AllowedRequestPayload] interface.
+// 2. Remove the fields that are not allowed by the [MinimizationProfile] of the context or of the [Client]
+// 3. Construct the request (i.e. attach the body, set the appropriate headers)
+// 4. Performs the request to the Account Protect API.
+// 5. Returns the response status code, the response body, and the potential error.
 //
 // An error may be returned in case of:
 //   - an error when performing the request
 //   - encoding/decoding the JSON payloads
 //   - the request timeout (see [ErrRequestTimeout])
 func performRequest[T AllowedRequestPayload](ctx context.Context, c *Client, endpoint string, payload *T) (int, []byte, error) {
+	pseudonymizePayload(c, payload)
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return -1, nil, fmt.Errorf("fail to marshal request payload: %w", err)
 	}
+	if profile := c.getMinimizationProfile(ctx); profile != nil {
+		body, err = profile.apply(body)
+		if err != nil {
+			return -1, nil, fmt.Errorf("fail to minimize request payload: %w", err)
+		}
+	}
 	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return -1, nil, fmt.Errorf("error when instancing new request: %w", err)
@@ -0,0 +1,38 @@
+package fraudsdkgo
+
+import (
+	"net/http"
+	"strings"
+)
+
+// clientHints lists the high-entropy client hints collected by the SDK.
+var clientHints = []string{
+	"Sec-CH-Device-Memory",
+	"Sec-CH-UA-Arch",
+	"Sec-CH-UA-Bitness",
+	"Sec-CH-UA-Form-Factors",
+	"Sec-CH-UA-Full-Version-List",
+	"Sec-CH-UA-Model",
+	"Sec-CH-UA-Platform-Version",
+	"Sec-CH-UA-WoW64",
+}
+
+// ClientHints returns the high-entropy client hints collected by the SDK.
+// Browsers only send them once they have been requested through the `Accept-CH` response header (see [SetAcceptCH]).
+// The returned slice is a copy and may be modified.
+func ClientHints() []string {
+	return append([]string(nil), clientHints...)
+}
+
+// SetAcceptCH sets the `Accept-CH` response header to request the [ClientHints] collected by the SDK
+// on the subsequent requests of the browser.
+// Additional client hints may be specified, they are appended to the [ClientHints].
+//
+// It must be called before the header is written, ideally on the responses of the pages preceding
+// the protected actions (e.g. the login page).
+func SetAcceptCH(w http.ResponseWriter, additionalHints ...string) {
+	hints := make([]string, 0, len(clientHints)+len(additionalHints))
+	hints = append(hints, clientHints...)
+	hints = append(hints, additionalHints...)
+	w.Header().Set("Accept-CH", strings.Join(hints, ", "))
+}
@@ -0,0 +1,37 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAcceptCH(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetAcceptCH(w)
+	assert.Equal(t, strings.Join(ClientHints(), ", "), w.Header().Get("Accept-CH"))
+
+	w = httptest.NewRecorder()
+	SetAcceptCH(w, "Sec-CH-Prefers-Color-Scheme")
+	assert.True(t, strings.HasSuffix(w.Header().Get("Accept-CH"), ", Sec-CH-Prefers-Color-Scheme"))
+	assert.Len(t, strings.Split(w.Header().Get("Accept-CH"), ", "), len(ClientHints())+1)
+}
+
+func TestClientHints(t *testing.T) {
+	hints := ClientHints()
+	assert.Contains(t, hints, "Sec-CH-UA-Model")
+
+	hints[0] = "Sec-CH-Prefers-Color-Scheme"
+	assert.Equal(t, "Sec-CH-Device-Memory", ClientHints()[0])
+}
+
+func ExampleSetAcceptCH() {
+	w := httptest.NewRecorder()
+	SetAcceptCH(w)
+
+	fmt.Println(w.Header().Get("Accept-CH"))
+	// Output: Sec-CH-Device-Memory, Sec-CH-UA-Arch, Sec-CH-UA-Bitness, Sec-CH-UA-Form-Factors, Sec-CH-UA-Full-Version-List, Sec-CH-UA-Model, Sec-CH-UA-Platform-Version, Sec-CH-UA-WoW64
+}
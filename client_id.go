@@ -0,0 +1,105 @@
+package fraudsdkgo
+
+import (
+	"net/http"
+)
+
+// ClientIDSourceType describes the possible types of source of the ClientID.
+type ClientIDSourceType string
+
+const (
+	CookieClientIDSource ClientIDSourceType = "cookie"
+	CustomClientIDSource ClientIDSourceType = "custom"
+	HeaderClientIDSource ClientIDSourceType = "header"
+	QueryClientIDSource  ClientIDSourceType = "query"
+)
+
+// ClientIDSource describes where the ClientID is read from in the incoming request.
+type ClientIDSource struct {
+	Type ClientIDSourceType
+	Name string
+
+	extract func(r *http.Request) string
+}
+
+// ClientIDFromHeader returns a [ClientIDSource] reading the ClientID from the given header.
+func ClientIDFromHeader(name string) ClientIDSource {
+	return ClientIDSource{
+		Type: HeaderClientIDSource,
+		Name: name,
+		extract: func(r *http.Request) string {
+			return r.Header.Get(name)
+		},
+	}
+}
+
+// ClientIDFromCookie returns a [ClientIDSource] reading the ClientID from the given cookie.
+func ClientIDFromCookie(name string) ClientIDSource {
+	return ClientIDSource{
+		Type: CookieClientIDSource,
+		Name: name,
+		extract: func(r *http.Request) string {
+			cookie, err := r.Cookie(name)
+			if err != nil {
+				return ""
+			}
+			return cookie.Value
+		},
+	}
+}
+
+// ClientIDFromQuery returns a [ClientIDSource] reading the ClientID from the given query parameter.
+func ClientIDFromQuery(name string) ClientIDSource {
+	return ClientIDSource{
+		Type: QueryClientIDSource,
+		Name: name,
+		extract: func(r *http.Request) string {
+			if r.URL == nil {
+				return ""
+			}
+			return r.URL.Query().Get(name)
+		},
+	}
+}
+
+// ClientIDFromFunc returns a [ClientIDSource] reading the ClientID with a custom function.
+// The name is used to identify the source, and the function should return an empty string if
+// the ClientID is not found.
+func ClientIDFromFunc(name string, extract func(r *http.Request) string) ClientIDSource {
+	return ClientIDSource{
+		Type:    CustomClientIDSource,
+		Name:    name,
+		extract: extract,
+	}
+}
+
+// DefaultClientIDSources lists the sources used to read the ClientID when none is configured:
+// the `X-DataDome-ClientID` header if the session by header feature is used, then the `datadome` cookie.
+var DefaultClientIDSources = []ClientIDSource{
+	ClientIDFromHeader("x-datadome-clientid"),
+	ClientIDFromCookie("datadome"),
+}
+
+// ResolveClientID returns the ClientID of the incoming request along with the [ClientIDSource] that supplied it.
+// The sources configured with [ClientWithClientIDSources] are used in order, the [DefaultClientIDSources] otherwise.
+// The returned boolean is false if no source supplied a ClientID.
+func (c *Client) ResolveClientID(r *http.Request) (string, ClientIDSource, bool) {
+	sources := c.ClientIDSources
+	if len(sources) == 0 {
+		sources = DefaultClientIDSources
+	}
+	return resolveClientID(r, sources)
+}
+
+// resolveClientID returns the first ClientID found in the sources, along with the source that supplied it.
+func resolveClientID(r *http.Request, sources []ClientIDSource) (string, ClientIDSource, bool) {
+	for _, source := range sources {
+		if source.extract == nil {
+			continue
+		}
+		if clientID := source.extract(r); clientID != "" {
+			return clientID, source, true
+		}
+	}
+	return "", ClientIDSource{}, false
+}
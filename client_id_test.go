@@ -0,0 +1,121 @@
+package fraudsdkgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clientIDContextKey struct{}
+
+func TestResolveClientID(t *testing.T) {
+	c, err := NewClient(
+		"your-api-key",
+		ClientWithClientIDSources(
+			ClientIDFromHeader("x-device-id"),
+			ClientIDFromCookie("brand_dd"),
+			ClientIDFromQuery("cid"),
+			ClientIDFromFunc("context", func(r *http.Request) string {
+				clientID, _ := r.Context().Value(clientIDContextKey{}).(string)
+				return clientID
+			}),
+		),
+	)
+	assert.Nil(t, err)
+
+	t.Run("From a header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ping?cid=from-query", nil)
+		r.Header.Set("X-Device-ID", "from-header")
+		r.AddCookie(&http.Cookie{Name: "brand_dd", Value: "from-cookie"})
+
+		clientID, source, ok := c.ResolveClientID(r)
+		assert.True(t, ok)
+		assert.Equal(t, "from-header", clientID)
+		assert.Equal(t, HeaderClientIDSource, source.Type)
+		assert.Equal(t, "x-device-id", source.Name)
+	})
+
+	t.Run("From a cookie", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ping?cid=from-query", nil)
+		r.AddCookie(&http.Cookie{Name: "brand_dd", Value: "from-cookie"})
+
+		clientID, source, ok := c.ResolveClientID(r)
+		assert.True(t, ok)
+		assert.Equal(t, "from-cookie", clientID)
+		assert.Equal(t, CookieClientIDSource, source.Type)
+	})
+
+	t.Run("From a query parameter", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ping?cid=from-query", nil)
+
+		clientID, source, ok := c.ResolveClientID(r)
+		assert.True(t, ok)
+		assert.Equal(t, "from-query", clientID)
+		assert.Equal(t, QueryClientIDSource, source.Type)
+	})
+
+	t.Run("From a custom function", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		r = r.WithContext(context.WithValue(r.Context(), clientIDContextKey{}, "from-context"))
+
+		clientID, source, ok := c.ResolveClientID(r)
+		assert.True(t, ok)
+		assert.Equal(t, "from-context", clientID)
+		assert.Equal(t, CustomClientIDSource, source.Type)
+		assert.Equal(t, "context", source.Name)
+	})
+
+	t.Run("Without any value", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		r.Header.Set("X-DataDome-ClientID", "ignored")
+
+		clientID, _, ok := c.ResolveClientID(r)
+		assert.False(t, ok)
+		assert.Equal(t, "", clientID)
+	})
+}
+
+func TestResolveClientID_Default(t *testing.T) {
+	c, err := NewClient("your-api-key")
+	assert.Nil(t, err)
+
+	clientID, source, ok := c.ResolveClientID(setupRequest())
+	assert.True(t, ok)
+	assert.Equal(t, "some_value", clientID)
+	assert.Equal(t, CookieClientIDSource, source.Type)
+	assert.Equal(t, "datadome", source.Name)
+}
+
+func TestWithClientIDSources(t *testing.T) {
+	t.Run("Used to build the header", func(t *testing.T) {
+		request := setupRequest()
+		request.Header.Set("X-Device-ID", "device-id")
+		c, err := NewClient("your-api-key", ClientWithClientIDSources(ClientIDFromHeader("X-Device-ID")))
+		assert.Nil(t, err)
+
+		header, err := c.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "device-id", header.ClientID)
+	})
+
+	t.Run("With an invalid source", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithClientIDSources(ClientIDSource{Type: HeaderClientIDSource, Name: "X-Device-ID"}))
+		assert.Nil(t, c)
+		assert.Equal(t, ErrWrongClientIDSourcesValue, err)
+	})
+}
+
+func ExampleClientWithClientIDSources() {
+	c, _ := NewClient("your-api-key", ClientWithClientIDSources(ClientIDFromHeader("X-Device-ID"), ClientIDFromCookie("brand_dd")))
+
+	r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	r.AddCookie(&http.Cookie{Name: "brand_dd", Value: "123456"})
+	clientID, source, _ := c.ResolveClientID(r)
+
+	fmt.Println(clientID, source.Type, source.Name)
+	// Output: 123456 cookie brand_dd
+}
@@ -1,11 +1,13 @@
 package fraudsdkgo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -168,6 +170,50 @@ func TestGetHeader_WithOptionalValues(t *testing.T) {
 	assert.Equal(t, "8", *header.SecCHDeviceMemory)
 }
 
+func TestGetHeader_WithExtendedClientHints(t *testing.T) {
+	request := setupRequest()
+
+	// add extended client hints and fetch metadata
+	request.Header.Set("Sec-CH-UA-Platform-Version", `"15.0.0"`)
+	request.Header.Set("Sec-CH-UA-Bitness", `"64"`)
+	request.Header.Set("Sec-CH-UA-WoW64", "?0")
+	request.Header.Set("Sec-CH-UA-Form-Factors", `"Desktop"`)
+	request.Header.Set("Sec-Fetch-Site", "same-origin")
+	request.Header.Set("Sec-Fetch-Mode", "navigate")
+	request.Header.Set("Sec-Fetch-Dest", "document")
+	request.Header.Set("Sec-Fetch-User", "?1")
+	request.Header.Set("Save-Data", "on")
+	request.Header.Set("DNT", "1")
+	request.Header.Set("Priority", "u=0, i")
+
+	c, err := NewClient("your-fraud-api-key")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, c)
+
+	header, err := c.buildHeader(request, &RequestMetadata{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `"15.0.0"`, *header.SecCHUAPlatformVersion)
+	assert.Equal(t, `"64"`, *header.SecCHUABitness)
+	assert.Equal(t, "?0", *header.SecCHUAWoW64)
+	assert.Equal(t, `"Desktop"`, *header.SecCHUAFormFactors)
+	assert.Equal(t, "same-origin", *header.SecFetchSite)
+	assert.Equal(t, "navigate", *header.SecFetchMode)
+	assert.Equal(t, "document", *header.SecFetchDest)
+	assert.Equal(t, "?1", *header.SecFetchUser)
+	assert.Equal(t, "on", *header.SaveData)
+	assert.Equal(t, "1", *header.DNT)
+	assert.Equal(t, "u=0, i", *header.Priority)
+
+	header, err = c.buildHeader(setupRequest(), &RequestMetadata{})
+
+	assert.Nil(t, err)
+	assert.Nil(t, header.SecCHUAPlatformVersion)
+	assert.Nil(t, header.SecFetchSite)
+	assert.Nil(t, header.DNT)
+}
+
 func TestGetHeader_OverrideInitialValues(t *testing.T) {
 	request := setupRequest()
 	c, err := NewClient("your-fraud-api-key")
@@ -201,6 +247,55 @@ func TestGetHeader_OverrideInitialValues(t *testing.T) {
 	assert.Equal(t, "grpc", header.Protocol)
 }
 
+func TestGetHeader_WithForwardedHeader(t *testing.T) {
+	request := setupRequest()
+	request.Header.Set("Forwarded", `for=203.0.113.1;proto=https;host="shop.example.com:8443", for=127.0.0.1`)
+	c, err := NewClient("your-fraud-api-key", ClientWithTrustedProxies([]string{"127.0.0.1"}), ClientWithForwardedHeader())
+
+	assert.Nil(t, err)
+	assert.NotNil(t, c)
+
+	header, err := c.buildHeader(request, &RequestMetadata{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "203.0.113.1", header.Addr)
+	assert.Equal(t, "https", header.Protocol)
+	assert.Equal(t, "shop.example.com:8443", header.Host)
+	assert.Equal(t, 8443, header.Port)
+	assert.Equal(t, "www.example.com", header.ServerHostname)
+}
+
+func TestGetHeader_WithForgedForwardedHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []ClientOption
+		want    string
+	}{
+		{"Without configuration", nil, "127.0.0.1"},
+		{"Trusted proxies", []ClientOption{ClientWithTrustedProxies([]string{"127.0.0.1"})}, "203.0.113.1"},
+		{"Trusted hops", []ClientOption{ClientWithTrustedHops(1)}, "203.0.113.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := setupRequest()
+			request.RemoteAddr = "127.0.0.1:1234"
+			request.Header.Set("X-Forwarded-For", "203.0.113.1")
+			request.Header.Set("Forwarded", `for=8.8.8.8;proto=https;host="evil.example:8443"`)
+			c, err := NewClient("your-fraud-api-key", tc.options...)
+			assert.Nil(t, err)
+
+			header, err := c.buildHeader(request, &RequestMetadata{})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, header.Addr)
+			assert.Equal(t, request.Host, header.Host)
+			assert.Equal(t, "http", header.Protocol)
+			assert.Equal(t, 80, header.Port)
+		})
+	}
+}
+
 func TestGetModule(t *testing.T) {
 	c, err := NewClient("your-fraud-api-key")
 
@@ -313,6 +408,124 @@ func TestCollectWithRequestMetadata(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestBuildHeaderFromMetadata(t *testing.T) {
+	c, _ := NewClient("your-fraud-api-key")
+
+	t.Run("With the required fields only", func(t *testing.T) {
+		addr := "192.168.1.1"
+		method := "POST"
+		header, err := c.buildHeaderFromMetadata(context.Background(), &RequestMetadata{
+			Addr:   &addr,
+			Method: &method,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "192.168.1.1", header.Addr)
+		assert.Equal(t, "POST", header.Method)
+		assert.Equal(t, "http", header.Protocol)
+		assert.Equal(t, 80, header.Port)
+		assert.Equal(t, "", header.UserAgent)
+		assert.Nil(t, header.SecCHUA)
+	})
+
+	t.Run("With optional fields", func(t *testing.T) {
+		addr := "192.168.1.1"
+		method := "POST"
+		proto := "https"
+		userAgent := strings.Repeat("a", 1000)
+		secCHUA := `"Chromium";v="114"`
+		header, err := c.buildHeaderFromMetadata(context.Background(), &RequestMetadata{
+			Addr:      &addr,
+			Method:    &method,
+			Protocol:  &proto,
+			UserAgent: &userAgent,
+			SecCHUA:   &secCHUA,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "https", header.Protocol)
+		assert.Equal(t, 443, header.Port)
+		assert.Len(t, header.UserAgent, 768)
+		assert.Equal(t, secCHUA, *header.SecCHUA)
+	})
+
+	t.Run("Without the required fields", func(t *testing.T) {
+		addr := "192.168.1.1"
+		_, err := c.buildHeaderFromMetadata(context.Background(), nil)
+		assert.Equal(t, ErrAddrMissing, err)
+
+		_, err = c.buildHeaderFromMetadata(context.Background(), &RequestMetadata{Addr: &addr})
+		assert.Equal(t, ErrMethodMissing, err)
+	})
+
+	t.Run("With an invalid Addr", func(t *testing.T) {
+		method := "POST"
+		for _, addr := range []string{"not-an-ip", "1.2.3.4:80", "[2001:db8::1]:443", "10.0.0.0/8"} {
+			_, err := c.buildHeaderFromMetadata(context.Background(), &RequestMetadata{Addr: &addr, Method: &method})
+			assert.ErrorIs(t, err, ErrWrongAddrValue, addr)
+		}
+
+		addr := " ::ffff:192.168.1.1 "
+		header, err := c.buildHeaderFromMetadata(context.Background(), &RequestMetadata{Addr: &addr, Method: &method})
+		assert.Nil(t, err)
+		assert.Equal(t, "192.168.1.1", header.Addr)
+	})
+}
+
+func TestValidateFromMetadata(t *testing.T) {
+	c, err := NewClient("your-fraud-api-key")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, c)
+
+	type contextKey struct{}
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+	mockEvent := &MockEvent{
+		ValidateFunc: func(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+			assert.Equal(t, "value", r.Context().Value(contextKey{}))
+			assert.Equal(t, "192.168.1.1", header.Addr)
+			return &ResponsePayload{
+				SuccessResponsePayload: SuccessResponsePayload{
+					Action: Allow,
+				},
+			}, nil
+		},
+	}
+
+	addr := "192.168.1.1"
+	method := "POST"
+	resp, err := c.ValidateFromMetadata(ctx, mockEvent, &RequestMetadata{Addr: &addr, Method: &method})
+	assert.Nil(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, Allow, resp.Action)
+
+	resp, err = c.ValidateFromMetadata(ctx, mockEvent, &RequestMetadata{Method: &method})
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrAddrMissing)
+}
+
+func TestCollectFromMetadata(t *testing.T) {
+	c, err := NewClient("your-fraud-api-key")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, c)
+
+	mockEvent := &MockEvent{
+		CollectFunc: func(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+			assert.Equal(t, "POST", r.Method)
+			return nil, nil
+		},
+	}
+
+	addr := "192.168.1.1"
+	method := "POST"
+	_, err = c.CollectFromMetadata(context.Background(), mockEvent, &RequestMetadata{Addr: &addr, Method: &method})
+	assert.Nil(t, err)
+
+	_, err = c.CollectFromMetadata(context.Background(), mockEvent, &RequestMetadata{Addr: &addr})
+	assert.ErrorIs(t, err, ErrMethodMissing)
+}
+
 func TestWithEndpoint(t *testing.T) {
 	t.Run("Instantiate without protocol", func(t *testing.T) {
 		endpoint := "api.example.org"
@@ -390,3 +603,201 @@ func ExampleClientWithTimeout() {
 	fmt.Println(c.Timeout)
 	// Output: 300
 }
+
+func TestWithTrustedProxies(t *testing.T) {
+	t.Run("With valid values", func(t *testing.T) {
+		client, err := NewClient(
+			"your-api-key",
+			ClientWithTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"}),
+		)
+
+		assert.NotNil(t, client)
+		assert.Nil(t, err)
+		assert.Len(t, client.trustedNetworks, 2)
+	})
+
+	t.Run("With an invalid value", func(t *testing.T) {
+		client, err := NewClient(
+			"your-api-key",
+			ClientWithTrustedProxies([]string{"10.0.0.0/8", "localhost"}),
+		)
+
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, ErrWrongTrustedProxiesValue)
+	})
+
+	t.Run("Together with trusted hops", func(t *testing.T) {
+		client, err := NewClient(
+			"your-api-key",
+			ClientWithTrustedProxies([]string{"10.0.0.0/8"}),
+			ClientWithTrustedHops(1),
+		)
+
+		assert.Nil(t, client)
+		assert.Equal(t, ErrTrustedProxiesConflict, err)
+	})
+
+	t.Run("Resolves the IP of the emitter", func(t *testing.T) {
+		request := setupRequest()
+		client, err := NewClient(
+			"your-api-key",
+			ClientWithTrustedProxies([]string{"127.0.0.1"}),
+		)
+		assert.Nil(t, err)
+
+		header, err := client.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "192.168.10.10", header.Addr)
+	})
+}
+
+func TestWithTrustedHops(t *testing.T) {
+	t.Run("With a positive integer", func(t *testing.T) {
+		request := setupRequest()
+		client, err := NewClient(
+			"your-api-key",
+			ClientWithTrustedHops(2),
+		)
+		assert.NotNil(t, client)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, client.TrustedHops)
+
+		header, err := client.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "192.168.10.10", header.Addr)
+	})
+
+	t.Run("With a negative integer", func(t *testing.T) {
+		client, err := NewClient(
+			"your-api-key",
+			ClientWithTrustedHops(-1),
+		)
+
+		assert.Nil(t, client)
+		assert.Equal(t, ErrWrongTrustedHopsValue, err)
+	})
+}
+
+func TestWithMissingIPPolicy(t *testing.T) {
+	t.Run("Fails by default", func(t *testing.T) {
+		request := setupRequest()
+		request.RemoteAddr = "@"
+		client, err := NewClient("your-api-key")
+		assert.Nil(t, err)
+		assert.Equal(t, MissingIPFail, client.MissingIPPolicy)
+
+		header, err := client.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, header)
+		assert.ErrorIs(t, err, ErrIPNotFound)
+	})
+
+	t.Run("Accepts a RemoteAddr without port", func(t *testing.T) {
+		request := setupRequest()
+		request.RemoteAddr = "::ffff:127.0.0.1"
+		client, err := NewClient("your-api-key")
+		assert.Nil(t, err)
+
+		header, err := client.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "127.0.0.1", header.Addr)
+	})
+
+	t.Run("With a fallback IP", func(t *testing.T) {
+		request := setupRequest()
+		request.RemoteAddr = "@"
+		client, err := NewClient("your-api-key", ClientWithFallbackIP("::ffff:192.0.2.1"))
+		assert.Nil(t, err)
+		assert.Equal(t, MissingIPFallback, client.MissingIPPolicy)
+		assert.Equal(t, "192.0.2.1", client.FallbackIP)
+
+		header, err := client.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "192.0.2.1", header.Addr)
+	})
+
+	t.Run("Omits the IP", func(t *testing.T) {
+		request := setupRequest()
+		request.RemoteAddr = "@"
+		client, err := NewClient("your-api-key", ClientWithMissingIPPolicy(MissingIPOmit))
+		assert.Nil(t, err)
+
+		header, err := client.buildHeader(request, &RequestMetadata{})
+		assert.Nil(t, err)
+		assert.Equal(t, "", header.Addr)
+	})
+
+	t.Run("With an invalid fallback IP", func(t *testing.T) {
+		client, err := NewClient("your-api-key", ClientWithFallbackIP("localhost"))
+		assert.Nil(t, client)
+		assert.Equal(t, ErrWrongFallbackIPValue, err)
+	})
+
+	t.Run("With an unknown policy", func(t *testing.T) {
+		client, err := NewClient("your-api-key", ClientWithMissingIPPolicy("ignore"))
+		assert.Nil(t, client)
+		assert.Equal(t, ErrWrongMissingIPPolicyValue, err)
+	})
+
+	t.Run("Normalizes the Addr of the RequestMetadata", func(t *testing.T) {
+		request := setupRequest()
+		client, err := NewClient("your-api-key")
+		assert.Nil(t, err)
+
+		addr := "fe80::1%eth0"
+		header, err := client.buildHeader(request, &RequestMetadata{Addr: &addr})
+		assert.Nil(t, err)
+		assert.Equal(t, "fe80::1", header.Addr)
+	})
+}
+
+func ExampleClientWithFallbackIP() {
+	c, _ := NewClient("your-api-key", ClientWithFallbackIP("192.0.2.1"))
+
+	fmt.Println(c.MissingIPPolicy, c.FallbackIP)
+	// Output: fallback 192.0.2.1
+}
+
+func ExampleClientWithTrustedProxies() {
+	c, _ := NewClient("your-api-key", ClientWithTrustedProxies([]string{"10.0.0.0/8"}))
+
+	fmt.Println(c.TrustedProxies)
+	// Output: [10.0.0.0/8]
+}
+
+func TestWithTruncationSizes(t *testing.T) {
+	request := setupRequest()
+	c, err := NewClient(
+		"your-api-key",
+		ClientWithTruncationSizes(map[ApiFields]int{UserAgent: 5}),
+		ClientWithTruncationSizes(map[ApiFields]int{AcceptLanguage: 1}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, map[ApiFields]int{UserAgent: 5, AcceptLanguage: 1}, c.TruncationSizes)
+
+	header, err := c.buildHeader(request, &RequestMetadata{})
+	assert.Nil(t, err)
+	assert.Equal(t, "über", header.UserAgent)
+	assert.Equal(t, "f", header.AcceptLanguage)
+	assert.Equal(t, "application/json", header.Accept)
+}
+
+func TestWithTruncationHook(t *testing.T) {
+	var reported []TruncatedField
+	c, err := NewClient(
+		"your-api-key",
+		ClientWithTruncationHook(func(ctx context.Context, fields []TruncatedField) {
+			reported = fields
+		}),
+	)
+	assert.Nil(t, err)
+
+	request := setupRequest()
+	_, err = c.buildHeader(request, &RequestMetadata{})
+	assert.Nil(t, err)
+	assert.Nil(t, reported)
+
+	request.Header.Set("user-agent", strings.Repeat("é", 500))
+	_, err = c.buildHeader(request, &RequestMetadata{})
+	assert.Nil(t, err)
+	assert.Equal(t, []TruncatedField{{Field: UserAgent, OriginalLength: 1000, Length: 768}}, reported)
+}
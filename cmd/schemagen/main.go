@@ -0,0 +1,409 @@
+// Command schemagen generates the JSON Schema documents of the request and response payloads
+// exchanged with the Account Protect API.
+//
+// The schemas are derived from the source code of the fraudsdkgo package: the structures and their
+// JSON tags, the enum constants, and the truncation limits of the `Header` fields used as `maxLength`.
+// The fields set by the SDK only, which are not exchanged with the Account Protect API, are marked
+// with the `schemagen:"-"` tag and left out of the schemas.
+//
+// Usage:
+//
+//	go run ./cmd/schemagen -dir . -out schemas
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// payloads lists the structures for which a schema is generated.
+var payloads = []string{
+	"LoginRequestPayload",
+	"RegistrationRequestPayload",
+	"AccountUpdateRequestPayload",
+	"PasswordUpdateRequestPayload",
+	"ResponsePayload",
+}
+
+// truncatedStructure is the structure whose fields are truncated according to getTruncationSize.
+const truncatedStructure = "Header"
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the fraudsdkgo package")
+	out := flag.String("out", "schemas", "directory where the schemas are written")
+	flag.Parse()
+
+	if err := generate(*dir, *out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate writes the schema of each payload to the output directory.
+func generate(dir string, out string) error {
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return fmt.Errorf("fail to create output directory: %w", err)
+	}
+	for _, name := range payloads {
+		schema, err := pkg.schema(name)
+		if err != nil {
+			return err
+		}
+		body, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("fail to marshal schema of %s: %w", name, err)
+		}
+		path := filepath.Join(out, name+".schema.json")
+		if err := os.WriteFile(path, append(body, '\n'), 0o644); err != nil {
+			return fmt.Errorf("fail to write schema of %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// packageInfo stores the declarations of the package needed to generate the schemas.
+type packageInfo struct {
+	structs         map[string]*ast.StructType
+	namedTypes      map[string]string
+	enums           map[string][]string
+	truncationSizes map[string]int
+}
+
+// loadPackage parses the Go files of the directory, excluding the test files.
+func loadPackage(dir string) (*packageInfo, error) {
+	pkg := &packageInfo{
+		structs:         map[string]*ast.StructType{},
+		namedTypes:      map[string]string{},
+		enums:           map[string][]string{},
+		truncationSizes: map[string]int{},
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("fail to parse %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				pkg.addGenDecl(d)
+			case *ast.FuncDecl:
+				if d.Name.Name == "getTruncationSize" {
+					if err := pkg.addTruncationSizes(d); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	return pkg, nil
+}
+
+// addGenDecl registers the structures, the named types and the enum constants of the declaration.
+func (pkg *packageInfo) addGenDecl(decl *ast.GenDecl) {
+	switch decl.Tok {
+	case token.TYPE:
+		for _, spec := range decl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			switch t := typeSpec.Type.(type) {
+			case *ast.StructType:
+				pkg.structs[typeSpec.Name.Name] = t
+			case *ast.Ident:
+				pkg.namedTypes[typeSpec.Name.Name] = t.Name
+			}
+		}
+	case token.CONST:
+		for _, spec := range decl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			typeName, ok := valueSpec.Type.(*ast.Ident)
+			if !ok || len(valueSpec.Values) != len(valueSpec.Names) {
+				continue
+			}
+			for _, value := range valueSpec.Values {
+				literal, ok := value.(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					continue
+				}
+				unquoted, err := strconv.Unquote(literal.Value)
+				if err != nil {
+					continue
+				}
+				pkg.enums[typeName.Name] = append(pkg.enums[typeName.Name], unquoted)
+			}
+		}
+	}
+}
+
+// addTruncationSizes reads the truncation sizes from the switch statement of the getTruncationSize function.
+// The negative sizes, keeping the end of the values, are converted to their absolute values.
+func (pkg *packageInfo) addTruncationSizes(decl *ast.FuncDecl) error {
+	for _, stmt := range decl.Body.List {
+		switchStmt, ok := stmt.(*ast.SwitchStmt)
+		if !ok {
+			continue
+		}
+		for _, clause := range switchStmt.Body.List {
+			caseClause := clause.(*ast.CaseClause)
+			if len(caseClause.Body) != 1 {
+				return fmt.Errorf("unexpected case body in getTruncationSize")
+			}
+			returnStmt, ok := caseClause.Body[0].(*ast.ReturnStmt)
+			if !ok || len(returnStmt.Results) != 1 {
+				return fmt.Errorf("unexpected case body in getTruncationSize")
+			}
+			size, err := parseIntExpr(returnStmt.Results[0])
+			if err != nil {
+				return err
+			}
+			if size < 0 {
+				size = -size
+			}
+			for _, expr := range caseClause.List {
+				if ident, ok := expr.(*ast.Ident); ok {
+					pkg.truncationSizes[ident.Name] = size
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseIntExpr returns the value of an integer literal, possibly negated.
+func parseIntExpr(expr ast.Expr) (int, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return strconv.Atoi(e.Value)
+	case *ast.UnaryExpr:
+		if e.Op == token.SUB {
+			value, err := parseIntExpr(e.X)
+			return -value, err
+		}
+	}
+	return 0, fmt.Errorf("unexpected truncation size expression")
+}
+
+// schema returns the JSON Schema document of the structure.
+func (pkg *packageInfo) schema(name string) (map[string]interface{}, error) {
+	b := &schemaBuilder{pkg: pkg, defs: map[string]interface{}{}}
+	schema, err := b.objectSchema(name)
+	if err != nil {
+		return nil, err
+	}
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = name + ".schema.json"
+	schema["title"] = name
+	if len(b.defs) > 0 {
+		schema["$defs"] = b.defs
+	}
+	return schema, nil
+}
+
+// schemaBuilder builds a schema and the definitions it references.
+type schemaBuilder struct {
+	pkg  *packageInfo
+	defs map[string]interface{}
+}
+
+// objectSchema returns the schema of the structure. The fields of the embedded structures are inlined.
+func (b *schemaBuilder) objectSchema(name string) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	required := []string{}
+	if err := b.addProperties(name, properties, &required); err != nil {
+		return nil, err
+	}
+	sort.Strings(required)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// addProperties adds the fields of the structure to the properties, following the encoding/json rules.
+func (b *schemaBuilder) addProperties(name string, properties map[string]interface{}, required *[]string) error {
+	structType, ok := b.pkg.structs[name]
+	if !ok {
+		return fmt.Errorf("unknown structure %s", name)
+	}
+	for _, field := range structType.Fields.List {
+		jsonName, omitEmpty, skip := parseJSONTag(field)
+		if skip || isSDKOnly(field) {
+			continue
+		}
+		if len(field.Names) == 0 && jsonName == "" {
+			embedded, ok := field.Type.(*ast.Ident)
+			if !ok {
+				return fmt.Errorf("unsupported embedded field in %s", name)
+			}
+			if err := b.addProperties(embedded.Name, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+			key := jsonName
+			if key == "" {
+				key = fieldName.Name
+			}
+			schema, nullable, err := b.typeSchema(field.Type)
+			if err != nil {
+				return fmt.Errorf("field %s of %s: %w", fieldName.Name, name, err)
+			}
+			if size, ok := b.pkg.truncationSizes[fieldName.Name]; ok && name == truncatedStructure && schema["type"] == "string" {
+				schema["maxLength"] = size
+			}
+			if nullable && !omitEmpty {
+				schema = map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+			}
+			properties[key] = schema
+			if !omitEmpty {
+				*required = append(*required, key)
+			}
+		}
+	}
+	return nil
+}
+
+// typeSchema returns the schema of the type, and whether the type is a pointer.
+func (b *schemaBuilder) typeSchema(expr ast.Expr) (map[string]interface{}, bool, error) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		schema, _, err := b.typeSchema(t.X)
+		return schema, true, err
+	case *ast.ArrayType:
+		items, _, err := b.typeSchema(t.Elt)
+		if err != nil {
+			return nil, false, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, true, nil
+	case *ast.Ident:
+		if schema, ok := basicTypeSchema(t.Name); ok {
+			return schema, false, nil
+		}
+		if err := b.define(t.Name); err != nil {
+			return nil, false, err
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name}, false, nil
+	}
+	return nil, false, fmt.Errorf("unsupported type %T", expr)
+}
+
+// define adds the definition of the named type to the definitions, if not already present.
+func (b *schemaBuilder) define(name string) error {
+	if _, ok := b.defs[name]; ok {
+		return nil
+	}
+	if underlying, ok := b.pkg.namedTypes[name]; ok {
+		schema, ok := basicTypeSchema(underlying)
+		if !ok {
+			return fmt.Errorf("unsupported underlying type %s of %s", underlying, name)
+		}
+		if values, ok := b.pkg.enums[name]; ok {
+			schema["enum"] = values
+		}
+		b.defs[name] = schema
+		return nil
+	}
+	// reserve the definition to support recursive structures
+	b.defs[name] = map[string]interface{}{}
+	schema, err := b.objectSchema(name)
+	if err != nil {
+		return err
+	}
+	b.defs[name] = schema
+	return nil
+}
+
+// basicTypeSchema returns the schema of the predeclared types.
+func basicTypeSchema(name string) (map[string]interface{}, bool) {
+	switch name {
+	case "string":
+		return map[string]interface{}{"type": "string"}, true
+	case "bool":
+		return map[string]interface{}{"type": "boolean"}, true
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return map[string]interface{}{"type": "integer"}, true
+	case "float32", "float64":
+		return map[string]interface{}{"type": "number"}, true
+	}
+	return nil, false
+}
+
+// parseJSONTag returns the name and the omitempty option of the json tag of the field,
+// and whether the field is ignored by encoding/json.
+func parseJSONTag(field *ast.Field) (string, bool, bool) {
+	if field.Tag == nil {
+		return "", false, false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false, false
+	}
+	value, ok := lookupTag(tag, "json")
+	if !ok {
+		return "", false, false
+	}
+	if value == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(value, ",")
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, false
+}
+
+// isSDKOnly returns whether the field is marked with the `schemagen:"-"` tag.
+func isSDKOnly(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return false
+	}
+	value, ok := lookupTag(tag, "schemagen")
+	return ok && value == "-"
+}
+
+// lookupTag returns the value of the key in the struct tag.
+func lookupTag(tag string, key string) (string, bool) {
+	for _, part := range strings.Fields(tag) {
+		if strings.HasPrefix(part, key+":") {
+			value, err := strconv.Unquote(strings.TrimPrefix(part, key+":"))
+			return value, err == nil
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	pkg, err := loadPackage("../..")
+	assert.Nil(t, err)
+
+	schema, err := pkg.schema("LoginRequestPayload")
+	assert.Nil(t, err)
+	assert.Equal(t, "LoginRequestPayload", schema["title"])
+	assert.Equal(t, []string{"account", "header", "module", "status"}, schema["required"])
+
+	defs := schema["$defs"].(map[string]interface{})
+	assert.Equal(t, []string{"failed", "succeeded"}, defs["LoginStatus"].(map[string]interface{})["enum"])
+
+	header := defs["Header"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, 1024, header["referer"].(map[string]interface{})["maxLength"])
+	assert.Equal(t, 512, header["xForwardedForIp"].(map[string]interface{})["maxLength"])
+	assert.Equal(t, 32, header["ja3"].(map[string]interface{})["maxLength"])
+	assert.NotContains(t, header["addr"], "maxLength")
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, header["port"])
+
+	user := defs["User"].(map[string]interface{})
+	assert.Equal(t, []string{"id"}, user["required"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}, user["properties"].(map[string]interface{})["externalUrls"])
+}
+
+func TestSchema_UnknownStructure(t *testing.T) {
+	pkg, err := loadPackage("../..")
+	assert.Nil(t, err)
+
+	_, err = pkg.schema("UnknownPayload")
+	assert.NotNil(t, err)
+}
+
+func TestGenerate_UpToDate(t *testing.T) {
+	out := t.TempDir()
+	assert.Nil(t, generate("../..", out))
+
+	for _, name := range payloads {
+		generated, err := os.ReadFile(filepath.Join(out, name+".schema.json"))
+		assert.Nil(t, err)
+		committed, err := os.ReadFile(filepath.Join("../../schemas", name+".schema.json"))
+		assert.Nil(t, err)
+		assert.Equal(t, string(generated), string(committed), "run go generate to update the schemas")
+	}
+}
+
+func TestSchema_SDKOnlyFields(t *testing.T) {
+	pkg, err := loadPackage("../..")
+	assert.Nil(t, err)
+
+	schema, err := pkg.schema("ResponsePayload")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"action"}, schema["required"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.NotContains(t, properties, "Status")
+	assert.NotContains(t, properties, "Policy")
+	assert.Contains(t, properties, "score")
+	assert.NotContains(t, schema["$defs"], "ResponseStatus")
+}
@@ -0,0 +1,169 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ContactUpdateOption describes the functional option signature to customize the [ContactUpdateEvent] behavior.
+type ContactUpdateOption func(*ContactUpdateEvent)
+
+// ContactUpdateWithElapsedTime is a functional option to set the time elapsed since the contact point update was requested.
+func ContactUpdateWithElapsedTime(elapsedTime time.Duration) ContactUpdateOption {
+	return func(e *ContactUpdateEvent) {
+		e.ElapsedTime = &elapsedTime
+	}
+}
+
+// ContactUpdateWithHashedValues is a functional option to send the SHA-256 hashes of the previous and new values
+// instead of the raw contact points.
+func ContactUpdateWithHashedValues() ContactUpdateOption {
+	return func(e *ContactUpdateEvent) {
+		e.Hashed = true
+	}
+}
+
+// ContactUpdateWithSession is a functional option to set the [Session] field.
+func ContactUpdateWithSession(session Session) ContactUpdateOption {
+	return func(e *ContactUpdateEvent) {
+		e.Session = &session
+	}
+}
+
+// ContactUpdateWithUser is a functional option to set the [User] field.
+func ContactUpdateWithUser(user User) ContactUpdateOption {
+	return func(e *ContactUpdateEvent) {
+		e.User = &user
+	}
+}
+
+// NewContactUpdateEvent instantiates a new [ContactUpdateEvent] that implements the [Event] interface.
+// The non-empty previous and new values are hashed when the [ContactUpdateWithHashedValues] option is used.
+func NewContactUpdateEvent(account string, contactType ContactType, previousValue string, newValue string, status ContactUpdateStatus, options ...ContactUpdateOption) *ContactUpdateEvent {
+	event := &ContactUpdateEvent{
+		Account:       account,
+		Action:        ContactUpdate,
+		ContactType:   contactType,
+		NewValue:      newValue,
+		PreviousValue: previousValue,
+		Status:        status,
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(event)
+	}
+
+	if event.Hashed {
+		normalize := normalizePhoneValue
+		if contactType == EmailContactType {
+			normalize = normalizeEmailValue
+		}
+		if value := normalize(previousValue); value != "" {
+			event.PreviousValue = hashValue(value)
+		}
+		if value := normalize(newValue); value != "" {
+			event.NewValue = hashValue(value)
+		}
+	}
+
+	return event
+}
+
+// Check returns the problems found in the fields of the [ContactUpdateEvent] (see [Checker]).
+func (e *ContactUpdateEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	checkOneOf(&pc, "contactType", e.ContactType, EmailContactType, PhoneContactType)
+	checkOneOf(&pc, "status", e.Status, ContactUpdateExpired, ContactUpdateRequested, ContactUpdateVerified)
+	pc.required("newValue", e.NewValue)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [ContactUpdateEvent] (see [Describer]).
+func (e *ContactUpdateEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
+// buildRequestPayload is used to construct the [ContactUpdateRequestPayload] based on the information stored
+// in the [ContactUpdateEvent] structure.
+func (e *ContactUpdateEvent) buildRequestPayload(module *Module, header *Header) *ContactUpdateRequestPayload {
+	var elapsedTimeMs *int64
+	if e.ElapsedTime != nil {
+		ms := e.ElapsedTime.Milliseconds()
+		elapsedTimeMs = &ms
+	}
+
+	return &ContactUpdateRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: e.Account,
+			Header:  *header,
+			Module:  *module,
+		},
+		ContactType:   e.ContactType,
+		ElapsedTimeMs: elapsedTimeMs,
+		Hashed:        e.Hashed,
+		NewValue:      e.NewValue,
+		PreviousValue: e.PreviousValue,
+		Session:       e.Session,
+		Status:        e.Status,
+		User:          e.User,
+	}
+}
+
+// Validate is used to construct the [ContactUpdateRequestPayload] based on the information stored
+// in the [ContactUpdateEvent] structure and performs the validation request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *ContactUpdateEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/validate/contact/update", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		resp := &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+			},
+		}
+		if errors.Is(err, ErrRequestTimeout) {
+			resp.Status = Timeout
+		} else {
+			resp.Status = Failure
+		}
+		return resp, fmt.Errorf("fail to validate contact update request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		return handleErrorResponse(responsePayload), nil
+	}
+	resp, err := decodeResponse[ResponsePayload](responsePayload)
+	if err != nil {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Failure,
+			},
+		}, err
+	}
+	resp.Status = OK
+	return resp, nil
+}
+
+// Collect is used to construct the [ContactUpdateRequestPayload] based on the information stored
+// in the [ContactUpdateEvent] structure and performs the enrichment request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *ContactUpdateEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/collect/contact/update", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("fail to collect contact update request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		responsePayload := handleErrorResponse(responsePayload)
+		return &responsePayload.ErrorResponsePayload, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,97 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactUpdateWithElapsedTime(t *testing.T) {
+	event := NewContactUpdateEvent("test-account", EmailContactType, "old@example.com", "new@example.com", ContactUpdateVerified, ContactUpdateWithElapsedTime(90*time.Second))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.ElapsedTime)
+	assert.Equal(t, 90*time.Second, *event.ElapsedTime)
+
+	payload := event.buildRequestPayload(&Module{}, &Header{})
+	assert.NotNil(t, payload.ElapsedTimeMs)
+	assert.Equal(t, int64(90000), *payload.ElapsedTimeMs)
+}
+
+func TestContactUpdateWithHashedValues(t *testing.T) {
+	t.Run("Email values are lowercased before hashing", func(t *testing.T) {
+		event := NewContactUpdateEvent("test-account", EmailContactType, " Old@Example.com", "new@example.com", ContactUpdateRequested, ContactUpdateWithHashedValues())
+		assert.True(t, event.Hashed)
+		assert.Equal(t, hashValue("old@example.com"), event.PreviousValue)
+		assert.Equal(t, hashValue("new@example.com"), event.NewValue)
+	})
+
+	t.Run("Phone values are stripped of whitespaces before hashing", func(t *testing.T) {
+		event := NewContactUpdateEvent("test-account", PhoneContactType, "+33 9 78 78 78 78", "+33612345678", ContactUpdateRequested, ContactUpdateWithHashedValues())
+		assert.True(t, event.Hashed)
+		assert.Equal(t, hashValue("+33978787878"), event.PreviousValue)
+		assert.Equal(t, hashValue("+33612345678"), event.NewValue)
+	})
+
+	t.Run("Empty values are not hashed", func(t *testing.T) {
+		event := NewContactUpdateEvent("test-account", EmailContactType, "", "new@example.com", ContactUpdateVerified, ContactUpdateWithHashedValues())
+		assert.True(t, event.Hashed)
+		assert.Equal(t, "", event.PreviousValue)
+		assert.Equal(t, hashValue("new@example.com"), event.NewValue)
+
+		event = NewContactUpdateEvent("test-account", PhoneContactType, "", "", ContactUpdateVerified, ContactUpdateWithHashedValues())
+		assert.Equal(t, "", event.PreviousValue)
+		assert.Equal(t, "", event.NewValue)
+		assert.Len(t, event.Check(), 1)
+	})
+}
+
+func TestContactUpdateWithSession(t *testing.T) {
+	sessionID := "123456"
+	createdAt := "1970-01-01T00:00:00Z"
+	session := Session{
+		ID:        &sessionID,
+		CreatedAt: &createdAt,
+	}
+
+	event := NewContactUpdateEvent("test-account", EmailContactType, "old@example.com", "new@example.com", ContactUpdateRequested, ContactUpdateWithSession(session))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Session)
+	assert.Equal(t, sessionID, *event.Session.ID)
+	assert.Equal(t, createdAt, *event.Session.CreatedAt)
+}
+
+func TestContactUpdateWithUser(t *testing.T) {
+	event := NewContactUpdateEvent("test-account", PhoneContactType, "+33978787878", "+33612345678", ContactUpdateExpired, ContactUpdateWithUser(User{ID: "123456"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.User)
+	assert.Equal(t, "123456", event.User.ID)
+}
+
+func TestNewContactUpdateEvent(t *testing.T) {
+	event := NewContactUpdateEvent("test-account", EmailContactType, "old@example.com", "new@example.com", ContactUpdateRequested)
+	assert.NotNil(t, event)
+	assert.Equal(t, "test-account", event.Account)
+	assert.Equal(t, ContactUpdate, event.Action)
+	assert.Equal(t, EmailContactType, event.ContactType)
+	assert.Equal(t, "old@example.com", event.PreviousValue)
+	assert.Equal(t, "new@example.com", event.NewValue)
+	assert.Equal(t, ContactUpdateRequested, event.Status)
+	assert.False(t, event.Hashed)
+	assert.Nil(t, event.ElapsedTime)
+}
+
+func ExampleContactUpdateWithElapsedTime() {
+	event := NewContactUpdateEvent("test-account", EmailContactType, "old@example.com", "new@example.com", ContactUpdateVerified, ContactUpdateWithElapsedTime(2*time.Minute))
+
+	fmt.Println(*event.ElapsedTime)
+	// Output: 2m0s
+}
+
+func ExampleContactUpdateWithHashedValues() {
+	event := NewContactUpdateEvent("test-account", EmailContactType, "old@example.com", "new@example.com", ContactUpdateRequested, ContactUpdateWithHashedValues())
+
+	fmt.Println(event.Hashed)
+	// Output: true
+}
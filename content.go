@@ -0,0 +1,152 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"unicode/utf8"
+)
+
+// linkRegexp matches the links that may be found in a user-generated content.
+var linkRegexp = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+
+// ContentOption describes the functional option signature to customize the [ContentEvent] behavior.
+type ContentOption func(*ContentEvent)
+
+// ContentWithID is a functional option to set the identifier of the content on the platform.
+func ContentWithID(contentID string) ContentOption {
+	return func(e *ContentEvent) {
+		e.ContentID = &contentID
+	}
+}
+
+// ContentWithLanguage is a functional option to set the language of the content (e.g. a BCP 47 language tag).
+func ContentWithLanguage(language string) ContentOption {
+	return func(e *ContentEvent) {
+		e.Language = &language
+	}
+}
+
+// ContentWithSession is a functional option to set the [Session] field.
+func ContentWithSession(session Session) ContentOption {
+	return func(e *ContentEvent) {
+		e.Session = &session
+	}
+}
+
+// ContentWithUser is a functional option to set the [User] field.
+func ContentWithUser(user User) ContentOption {
+	return func(e *ContentEvent) {
+		e.User = &user
+	}
+}
+
+// NewContentEvent instantiates a new [ContentEvent] that implements the [Event] interface.
+// The content itself is never sent to the Account Protect API: only its length in characters,
+// the number of links it contains and its SHA-256 hash are extracted.
+func NewContentEvent(account string, contentType UserContentType, content string, options ...ContentOption) *ContentEvent {
+	event := &ContentEvent{
+		Account:     account,
+		Action:      ContentPosting,
+		ContentType: contentType,
+		Hash:        hashValue(content),
+		Length:      utf8.RuneCountInString(content),
+		LinkCount:   len(linkRegexp.FindAllStringIndex(content, -1)),
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(event)
+	}
+
+	return event
+}
+
+// Check returns the problems found in the fields of the [ContentEvent] (see [Checker]).
+func (e *ContentEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	checkOneOf(&pc, "contentType", e.ContentType, OtherUserContentType, CommentContent, MessageContent, PostContent, ReviewContent)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [ContentEvent] (see [Describer]).
+func (e *ContentEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
+// buildRequestPayload is used to construct the [ContentRequestPayload] based on the information stored
+// in the [ContentEvent] structure.
+func (e *ContentEvent) buildRequestPayload(module *Module, header *Header) *ContentRequestPayload {
+	return &ContentRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: e.Account,
+			Header:  *header,
+			Module:  *module,
+		},
+		ContentID:   e.ContentID,
+		ContentType: e.ContentType,
+		Hash:        e.Hash,
+		Language:    e.Language,
+		Length:      e.Length,
+		LinkCount:   e.LinkCount,
+		Session:     e.Session,
+		User:        e.User,
+	}
+}
+
+// Validate is used to construct the [ContentRequestPayload] based on the information stored
+// in the [ContentEvent] structure and performs the validation request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *ContentEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/validate/content", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		resp := &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+			},
+		}
+		if errors.Is(err, ErrRequestTimeout) {
+			resp.Status = Timeout
+		} else {
+			resp.Status = Failure
+		}
+		return resp, fmt.Errorf("fail to validate content request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		return handleErrorResponse(responsePayload), nil
+	}
+	resp, err := decodeResponse[ResponsePayload](responsePayload)
+	if err != nil {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Failure,
+			},
+		}, err
+	}
+	resp.Status = OK
+	return resp, nil
+}
+
+// Collect is used to construct the [ContentRequestPayload] based on the information stored
+// in the [ContentEvent] structure and performs the enrichment request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *ContentEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/collect/content", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("fail to collect content request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		responsePayload := handleErrorResponse(responsePayload)
+		return &responsePayload.ErrorResponsePayload, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,74 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentWithID(t *testing.T) {
+	event := NewContentEvent("test-account", ReviewContent, "Great product", ContentWithID("review-1"))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.ContentID)
+	assert.Equal(t, "review-1", *event.ContentID)
+}
+
+func TestContentWithLanguage(t *testing.T) {
+	event := NewContentEvent("test-account", CommentContent, "Très bien", ContentWithLanguage("fr"))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Language)
+	assert.Equal(t, "fr", *event.Language)
+}
+
+func TestContentWithSession(t *testing.T) {
+	sessionID := "123456"
+	session := Session{
+		ID: &sessionID,
+	}
+
+	event := NewContentEvent("test-account", PostContent, "Hello", ContentWithSession(session))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Session)
+	assert.Equal(t, sessionID, *event.Session.ID)
+}
+
+func TestContentWithUser(t *testing.T) {
+	event := NewContentEvent("test-account", MessageContent, "Hello", ContentWithUser(User{ID: "123456"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.User)
+	assert.Equal(t, "123456", event.User.ID)
+}
+
+func TestNewContentEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		wantLength    int
+		wantLinkCount int
+	}{
+		{"Empty content", "", 0, 0},
+		{"Without links", "Great product", 13, 0},
+		{"Multi-byte characters", "Très bien", 9, 0},
+		{"With links", "Buy now at https://example.com/deal and www.example.org or HTTP://EXAMPLE.NET", 77, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			event := NewContentEvent("test-account", ReviewContent, tc.content)
+			assert.Equal(t, "test-account", event.Account)
+			assert.Equal(t, ContentPosting, event.Action)
+			assert.Equal(t, ReviewContent, event.ContentType)
+			assert.Equal(t, tc.wantLength, event.Length)
+			assert.Equal(t, tc.wantLinkCount, event.LinkCount)
+			assert.Equal(t, hashValue(tc.content), event.Hash)
+		})
+	}
+}
+
+func ExampleNewContentEvent() {
+	event := NewContentEvent("test-account", ReviewContent, "Visit https://example.com")
+
+	fmt.Println(event.Length, event.LinkCount)
+	// Output: 25 1
+}
@@ -0,0 +1,157 @@
+package fraudsdkgo
+
+import (
+	"context"
+	"net/http"
+)
+
+// EventBuilder describes the signature of the function building the [Event] to validate from the incoming request.
+// Returning a nil [Event] skips the validation of the request.
+type EventBuilder func(r *http.Request) (Event, error)
+
+// EnforcementOption describes the functional option signature to customize the enforcement middleware.
+type EnforcementOption func(*enforcementConfig)
+
+// enforcementConfig stores the behaviors of the enforcement middleware for each [ResponseAction].
+type enforcementConfig struct {
+	challengeHandler http.Handler
+	denyBody         []byte
+	denyStatus       int
+	errorHook        func(r *http.Request, err error)
+	reviewHook       func(r *http.Request, response *ResponsePayload)
+}
+
+// responsePayloadContextKey is the key used to store the [ResponsePayload] in the context of the request.
+type responsePayloadContextKey struct{}
+
+// EnforcementWithDenyResponse is a functional option to set the status code and the body of the response
+// sent when the Account Protect API recommends to [Deny] the request.
+// The default response is a `403 Forbidden` status code with an empty body.
+func EnforcementWithDenyResponse(status int, body []byte) EnforcementOption {
+	return func(cfg *enforcementConfig) {
+		cfg.denyStatus = status
+		cfg.denyBody = body
+	}
+}
+
+// EnforcementWithChallengeRedirect is a functional option to redirect the emitter to the URL,
+// with a `302 Found` status code, when the Account Protect API recommends to [Challenge] the request.
+func EnforcementWithChallengeRedirect(url string) EnforcementOption {
+	return func(cfg *enforcementConfig) {
+		cfg.challengeHandler = http.RedirectHandler(url, http.StatusFound)
+	}
+}
+
+// EnforcementWithChallengeHandler is a functional option to set the [http.Handler] serving the request
+// when the Account Protect API recommends to [Challenge] it, e.g. to display a CAPTCHA.
+// The [ResponsePayload] is available in the context of the request (see [ResponsePayloadFromContext]).
+// Without challenge configuration, the challenged requests receive the deny response.
+func EnforcementWithChallengeHandler(handler http.Handler) EnforcementOption {
+	return func(cfg *enforcementConfig) {
+		cfg.challengeHandler = handler
+	}
+}
+
+// EnforcementWithReviewHook is a functional option to set the function called when the Account Protect API
+// recommends to [Review] the request, e.g. to flag the account for a manual review.
+// The request is then passed to the next handler.
+func EnforcementWithReviewHook(hook func(r *http.Request, response *ResponsePayload)) EnforcementOption {
+	return func(cfg *enforcementConfig) {
+		cfg.reviewHook = hook
+	}
+}
+
+// EnforcementWithErrorHook is a functional option to set the function called when the [Event] cannot be built
+// or the validation fails, e.g. to log the error. The request is then passed to the next handler.
+func EnforcementWithErrorHook(hook func(r *http.Request, err error)) EnforcementOption {
+	return func(cfg *enforcementConfig) {
+		cfg.errorHook = hook
+	}
+}
+
+// NewEnforcementMiddleware returns a net/http middleware validating the incoming requests with the [Client]
+// and acting on the recommendation of the Account Protect API:
+//   - [Allow]: the request is passed to the next handler
+//   - [Deny]: the deny response is sent (see [EnforcementWithDenyResponse])
+//   - [Challenge]: the challenge handler serves the request (see [EnforcementWithChallengeRedirect]
+//     and [EnforcementWithChallengeHandler])
+//   - [Review]: the review hook is called and the request is passed to the next handler
+//
+// The [Event] is built from the request with the [EventBuilder]. The [ResponsePayload] is stored in the context
+// of the request passed to the handlers and can be retrieved with [ResponsePayloadFromContext].
+// As for the [Client], the requests are passed to the next handler in case of error.
+func NewEnforcementMiddleware(c *Client, build EventBuilder, options ...EnforcementOption) func(http.Handler) http.Handler {
+	cfg := &enforcementConfig{
+		denyStatus: http.StatusForbidden,
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			event, err := build(r)
+			if err != nil {
+				cfg.reportError(r, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if event == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			response, err := c.Validate(r, event)
+			if err != nil {
+				cfg.reportError(r, err)
+			}
+			if response == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), responsePayloadContextKey{}, response))
+
+			switch response.Action {
+			case Deny:
+				cfg.deny(w)
+			case Challenge:
+				if cfg.challengeHandler != nil {
+					cfg.challengeHandler.ServeHTTP(w, r)
+				} else {
+					cfg.deny(w)
+				}
+			case Review:
+				if cfg.reviewHook != nil {
+					cfg.reviewHook(r, response)
+				}
+				next.ServeHTTP(w, r)
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// ResponsePayloadFromContext returns the [ResponsePayload] stored in the context of the request by
+// the enforcement middleware (see [NewEnforcementMiddleware]), or nil if the request has not been validated.
+func ResponsePayloadFromContext(ctx context.Context) *ResponsePayload {
+	response, _ := ctx.Value(responsePayloadContextKey{}).(*ResponsePayload)
+	return response
+}
+
+// deny sends the deny response.
+func (cfg *enforcementConfig) deny(w http.ResponseWriter) {
+	w.WriteHeader(cfg.denyStatus)
+	if len(cfg.denyBody) > 0 {
+		_, _ = w.Write(cfg.denyBody)
+	}
+}
+
+// reportError calls the error hook if any.
+func (cfg *enforcementConfig) reportError(r *http.Request, err error) {
+	if cfg.errorHook != nil {
+		cfg.errorHook(r, err)
+	}
+}
@@ -0,0 +1,175 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupEnforcementServer(action ResponseAction) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"action":"%s","status":"ok","score":50}`, action)
+	}))
+}
+
+func buildLoginEvent(r *http.Request) (Event, error) {
+	return NewLoginEvent("test-account", Succeeded), nil
+}
+
+func TestEnforcementMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     ResponseAction
+		options    []EnforcementOption
+		wantStatus int
+		wantBody   string
+		wantNext   bool
+	}{
+		{"Allow passes through", Allow, nil, http.StatusOK, "next", true},
+		{"Deny with default response", Deny, nil, http.StatusForbidden, "", false},
+		{"Deny with custom response", Deny, []EnforcementOption{EnforcementWithDenyResponse(http.StatusUnauthorized, []byte("denied"))}, http.StatusUnauthorized, "denied", false},
+		{"Challenge without handler is denied", Challenge, nil, http.StatusForbidden, "", false},
+		{"Challenge with custom handler", Challenge, []EnforcementOption{EnforcementWithChallengeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("captcha " + string(ResponsePayloadFromContext(r.Context()).Action)))
+		}))}, http.StatusOK, "captcha challenge", false},
+		{"Review passes through", Review, nil, http.StatusOK, "next", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := setupEnforcementServer(tc.action)
+			defer server.Close()
+			c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+			assert.Nil(t, err)
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				response := ResponsePayloadFromContext(r.Context())
+				if assert.NotNil(t, response) {
+					assert.Equal(t, tc.action, response.Action)
+				}
+				_, _ = w.Write([]byte("next"))
+			})
+
+			w := httptest.NewRecorder()
+			NewEnforcementMiddleware(c, buildLoginEvent, tc.options...)(next).ServeHTTP(w, setupRequest())
+
+			assert.Equal(t, tc.wantNext, called)
+			assert.Equal(t, tc.wantStatus, w.Code)
+			body, _ := io.ReadAll(w.Body)
+			assert.Equal(t, tc.wantBody, string(body))
+		})
+	}
+}
+
+func TestEnforcementMiddlewareChallengeRedirect(t *testing.T) {
+	server := setupEnforcementServer(Challenge)
+	defer server.Close()
+	c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+	assert.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	middleware := NewEnforcementMiddleware(c, buildLoginEvent, EnforcementWithChallengeRedirect("/challenge"))
+	middleware(http.NotFoundHandler()).ServeHTTP(w, setupRequest())
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/challenge", w.Header().Get("Location"))
+}
+
+func TestEnforcementMiddlewareReviewHook(t *testing.T) {
+	server := setupEnforcementServer(Review)
+	defer server.Close()
+	c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+	assert.Nil(t, err)
+
+	var flagged *ResponsePayload
+	hook := func(r *http.Request, response *ResponsePayload) {
+		flagged = response
+	}
+
+	w := httptest.NewRecorder()
+	NewEnforcementMiddleware(c, buildLoginEvent, EnforcementWithReviewHook(hook))(http.NotFoundHandler()).ServeHTTP(w, setupRequest())
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	if assert.NotNil(t, flagged) {
+		assert.Equal(t, Review, flagged.Action)
+		if assert.NotNil(t, flagged.Score) {
+			assert.Equal(t, 50, *flagged.Score)
+		}
+	}
+}
+
+func TestEnforcementMiddlewareFailOpen(t *testing.T) {
+	server := setupEnforcementServer(Deny)
+	defer server.Close()
+	c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL), ClientWithStrictValidation())
+	assert.Nil(t, err)
+
+	errBuild := errors.New("no account")
+
+	tests := []struct {
+		name        string
+		build       EventBuilder
+		wantErr     error
+		wantPayload bool
+	}{
+		{"Builder error", func(r *http.Request) (Event, error) { return nil, errBuild }, errBuild, false},
+		{"Nil event", func(r *http.Request) (Event, error) { return nil, nil }, nil, false},
+		{"Invalid event", func(r *http.Request) (Event, error) { return NewLoginEvent("", Succeeded), nil }, ErrInvalidEvent, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var reported error
+			hook := func(r *http.Request, err error) {
+				reported = err
+			}
+
+			var payload *ResponsePayload
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				payload = ResponsePayloadFromContext(r.Context())
+			})
+
+			w := httptest.NewRecorder()
+			NewEnforcementMiddleware(c, tc.build, EnforcementWithErrorHook(hook))(next).ServeHTTP(w, setupRequest())
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.ErrorIs(t, reported, tc.wantErr)
+			assert.Equal(t, tc.wantPayload, payload != nil)
+		})
+	}
+}
+
+func ExampleNewEnforcementMiddleware() {
+	c, err := NewClient("your-api-key")
+	if err != nil {
+		return
+	}
+
+	build := func(r *http.Request) (Event, error) {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return NewLoginEvent(r.PostForm.Get("username"), Succeeded), nil
+	}
+
+	middleware := NewEnforcementMiddleware(c, build,
+		EnforcementWithDenyResponse(http.StatusForbidden, []byte("Access denied")),
+		EnforcementWithChallengeRedirect("/challenge"),
+		EnforcementWithReviewHook(func(r *http.Request, response *ResponsePayload) {
+			fmt.Println("login to review:", r.PostForm.Get("username"))
+		}),
+	)
+
+	http.Handle("/login", middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if response := ResponsePayloadFromContext(r.Context()); response != nil {
+			fmt.Println("recommended action:", response.Action)
+		}
+	})))
+}
@@ -3,7 +3,28 @@ package fraudsdkgo
 import "errors"
 
 var (
-	ErrKeyMissing        = errors.New("FraudAPIKey must be defined")
-	ErrRequestTimeout    = errors.New("request to Account Protect API timeout")
-	ErrWrongTimeoutValue = errors.New("Timeout must be a positive integer")
+	ErrAccessListsNotLoaded          = errors.New("AccessLists can only be reloaded by a Client created with NewClient")
+	ErrAccountNotFound               = errors.New("account of the login not found in the request")
+	ErrAddrMissing                   = errors.New("Addr must be defined in the RequestMetadata")
+	ErrIPNotFound                    = errors.New("IP of the emitter not found in the request")
+	ErrInvalidEmail                  = errors.New("value is not a valid email address")
+	ErrInvalidEvent                  = errors.New("event is invalid")
+	ErrInvalidPhone                  = errors.New("value is not a valid phone number")
+	ErrInvalidTimestamp              = errors.New("value is not a valid RFC 3339 timestamp")
+	ErrKeyMissing                    = errors.New("FraudAPIKey must be defined")
+	ErrMethodMissing                 = errors.New("Method must be defined in the RequestMetadata")
+	ErrMinimizationViolation         = errors.New("payload does not comply with the MinimizationProfile")
+	ErrRequestTimeout                = errors.New("request to Account Protect API timeout")
+	ErrTrustedProxiesConflict        = errors.New("TrustedProxies and TrustedHops cannot be used together")
+	ErrWrongAccessListsValue         = errors.New("AccessLists must be valid lists of accounts, IP addresses or CIDR ranges and ClientIDs")
+	ErrWrongAddrValue                = errors.New("Addr must be a valid IP address without port in the RequestMetadata")
+	ErrWrongClientIDSourcesValue     = errors.New("ClientIDSources must be created with the ClientIDFrom functions")
+	ErrWrongFallbackIPValue          = errors.New("FallbackIP must be a valid IP address")
+	ErrWrongMinimizationProfileValue = errors.New("MinimizationProfile fields must be known fields of the payloads")
+	ErrWrongMissingIPPolicyValue     = errors.New("MissingIPPolicy must be one of the MissingIPPolicy constants")
+	ErrWrongPolicyValue              = errors.New("Policy rules must have known actions and valid conditions")
+	ErrWrongPseudonymizationKeyValue = errors.New("PseudonymizationKey must not be empty")
+	ErrWrongTimeoutValue             = errors.New("Timeout must be a positive integer")
+	ErrWrongTrustedHopsValue         = errors.New("TrustedHops must be a positive integer")
+	ErrWrongTrustedProxiesValue      = errors.New("TrustedProxies must be valid IP addresses or CIDR ranges")
 )
@@ -0,0 +1,95 @@
+package fraudsdkgo
+
+import (
+	"strings"
+)
+
+// forwardedElement describes an element of the `Forwarded` header as defined in RFC 7239.
+// Each proxy appends a new element describing the request it received.
+type forwardedElement struct {
+	By    string
+	For   string
+	Host  string
+	Proto string
+}
+
+// parseForwardedHeader parses the values of the `Forwarded` headers of a request.
+// The elements are returned in the order they were appended, i.e. from the emitter to the last proxy.
+// Unknown parameters are ignored, and the elements without any known parameter are skipped.
+func parseForwardedHeader(values []string) []forwardedElement {
+	elements := []forwardedElement{}
+	for _, value := range values {
+		for _, rawElement := range splitForwarded(value, ',') {
+			var element forwardedElement
+			found := false
+			for _, pair := range splitForwarded(rawElement, ';') {
+				key, val, ok := strings.Cut(pair, "=")
+				if !ok {
+					continue
+				}
+				val = unquoteForwarded(strings.TrimSpace(val))
+				switch strings.ToLower(strings.TrimSpace(key)) {
+				case "by":
+					element.By = val
+				case "for":
+					element.For = val
+				case "host":
+					element.Host = val
+				case "proto":
+					element.Proto = strings.ToLower(val)
+				default:
+					continue
+				}
+				found = true
+			}
+			if found {
+				elements = append(elements, element)
+			}
+		}
+	}
+	return elements
+}
+
+// splitForwarded splits the value on the given separator, ignoring the separators located
+// inside a quoted-string. The parts are trimmed and the empty ones are skipped.
+func splitForwarded(value string, separator byte) []string {
+	parts := []string{}
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && inQuotes:
+			i++
+		case value[i] == '"':
+			inQuotes = !inQuotes
+		case value[i] == separator && !inQuotes:
+			if part := strings.TrimSpace(value[start:i]); part != "" {
+				parts = append(parts, part)
+			}
+			start = i + 1
+		}
+	}
+	if start < len(value) {
+		if part := strings.TrimSpace(value[start:]); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// unquoteForwarded returns the content of a quoted-string, with the quoted-pairs unescaped.
+// The value is returned unchanged if it is a token.
+func unquoteForwarded(value string) string {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return value
+	}
+	value = value[1 : len(value)-1]
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+		}
+		builder.WriteByte(value[i])
+	}
+	return builder.String()
+}
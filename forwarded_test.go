@@ -0,0 +1,70 @@
+package fraudsdkgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseForwardedHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   []forwardedElement
+	}{
+		{
+			name:   "Single element",
+			values: []string{"for=192.0.2.60;proto=http;by=203.0.113.43;host=example.com"},
+			want:   []forwardedElement{{By: "203.0.113.43", For: "192.0.2.60", Host: "example.com", Proto: "http"}},
+		},
+		{
+			name:   "Case-insensitive parameter names",
+			values: []string{"For=192.0.2.60;PROTO=HTTPS"},
+			want:   []forwardedElement{{For: "192.0.2.60", Proto: "https"}},
+		},
+		{
+			name:   "Quoted IPv6 with port",
+			values: []string{`for="[2001:db8:cafe::17]:4711"`},
+			want:   []forwardedElement{{For: "[2001:db8:cafe::17]:4711"}},
+		},
+		{
+			name:   "Obfuscated identifiers",
+			values: []string{"for=_hidden, for=unknown;by=_SEVKISEK"},
+			want:   []forwardedElement{{For: "_hidden"}, {By: "_SEVKISEK", For: "unknown"}},
+		},
+		{
+			name:   "Multiple elements and headers",
+			values: []string{"for=192.0.2.43, for=198.51.100.17", "for=203.0.113.60;proto=https"},
+			want:   []forwardedElement{{For: "192.0.2.43"}, {For: "198.51.100.17"}, {For: "203.0.113.60", Proto: "https"}},
+		},
+		{
+			name:   "Separators and escapes inside quoted-strings",
+			values: []string{`for=192.0.2.43;host="ex\"am;ple,com"`},
+			want:   []forwardedElement{{For: "192.0.2.43", Host: `ex"am;ple,com`}},
+		},
+		{
+			name:   "Malformed and unknown parameters",
+			values: []string{"garbage, ext=value, ;for=192.0.2.43;"},
+			want:   []forwardedElement{{For: "192.0.2.43"}},
+		},
+		{
+			name:   "Empty header",
+			values: []string{""},
+			want:   []forwardedElement{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseForwardedHeader(tc.values)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUnquoteForwarded(t *testing.T) {
+	assert.Equal(t, "192.0.2.43", unquoteForwarded("192.0.2.43"))
+	assert.Equal(t, "[2001:db8::1]", unquoteForwarded(`"[2001:db8::1]"`))
+	assert.Equal(t, `a"b\c`, unquoteForwarded(`"a\"b\\c"`))
+	assert.Equal(t, `"`, unquoteForwarded(`"`))
+}
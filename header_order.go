@@ -0,0 +1,293 @@
+package fraudsdkgo
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxHeaderBlockSize is the maximal size of a request header block parsed to capture the header order.
+// The capture stops for the connection if a header block is larger.
+const maxHeaderBlockSize = 1 << 16
+
+// tlsHandshakeRecordType is the first byte of a TLS connection, which starts with a handshake record.
+const tlsHandshakeRecordType = 0x16
+
+// headerOrderContextKey is the key used to store the [headerOrderConn] in the context of the connection.
+type headerOrderContextKey struct{}
+
+// NewHeaderOrderListener wraps the [net.Listener] to capture the order and the casing of the header names
+// of the incoming requests, which are lost once parsed into an [http.Header].
+// The [http.Server] must also use [HeaderOrderConnContext] as its ConnContext function.
+//
+// The capture only works for HTTP/1.x requests received in cleartext, e.g. behind a TLS-terminating proxy.
+// It is disabled for the connections upgraded to another protocol and for the connections pipelining requests,
+// i.e. sending a request before the response to the previous one is written.
+//
+// When the TLS is terminated by the [http.Server] (e.g. with ServeTLS, which wraps the listener in a TLS listener),
+// the listener reads the encrypted bytes: the capture is disabled as soon as the TLS handshake is detected,
+// and no header order is reported.
+func NewHeaderOrderListener(ln net.Listener) net.Listener {
+	return &headerOrderListener{Listener: ln}
+}
+
+// HeaderOrderConnContext attaches the header order captured by a listener created with
+// [NewHeaderOrderListener] to the context of the connection.
+// It can be used as the ConnContext function of an [http.Server].
+func HeaderOrderConnContext(ctx context.Context, c net.Conn) context.Context {
+	if conn, ok := c.(*headerOrderConn); ok {
+		return context.WithValue(ctx, headerOrderContextKey{}, conn)
+	}
+	return ctx
+}
+
+// headerOrderListener is a [net.Listener] returning [headerOrderConn] connections.
+type headerOrderListener struct {
+	net.Listener
+}
+
+// Accept waits for and returns the next connection, wrapped to capture the header order.
+func (l *headerOrderListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &headerOrderConn{Conn: c}, nil
+}
+
+// headerOrderParserState describes the state of the parser of the request stream.
+type headerOrderParserState int
+
+const (
+	parsingHeader headerOrderParserState = iota
+	parsingBody
+	parsingChunkSize
+	parsingChunkData
+	parsingTrailer
+	parsingStopped
+)
+
+// headerOrderConn is a [net.Conn] parsing the bytes read by the server to extract the header names
+// of each request. The body of the requests is skipped based on the `Content-Length` and
+// `Transfer-Encoding` headers.
+//
+// The header names are cleared when a new request starts and when the parser stops, so the names of
+// another request are never reported.
+type headerOrderConn struct {
+	net.Conn
+
+	mu          sync.Mutex
+	state       headerOrderParserState
+	buffer      []byte
+	remaining   int64
+	headerNames []string
+	unanswered  bool
+}
+
+// Read reads data from the connection and feeds the parser.
+func (c *headerOrderConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.mu.Lock()
+		c.parse(b[:n])
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+// Write writes data to the connection, which answers the last request read on the connection.
+func (c *headerOrderConn) Write(b []byte) (int, error) {
+	c.answer()
+	return c.Conn.Write(b)
+}
+
+// answer records that the response to the last request read on the connection is being written.
+func (c *headerOrderConn) answer() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unanswered = false
+}
+
+// lastHeaderNames returns the header names of the last request header block read on the connection.
+func (c *headerOrderConn) lastHeaderNames() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.headerNames
+}
+
+// parse consumes the data read on the connection.
+func (c *headerOrderConn) parse(data []byte) {
+	for len(data) > 0 {
+		switch c.state {
+		case parsingHeader, parsingChunkSize, parsingTrailer:
+			if c.state == parsingHeader && len(c.buffer) == 0 && data[0] != '\r' && data[0] != '\n' {
+				if data[0] == tlsHandshakeRecordType {
+					// the connection is encrypted
+					c.stop()
+					return
+				}
+				// a new request starts
+				c.headerNames = nil
+			}
+			index := bytes.IndexByte(data, '\n')
+			if index < 0 {
+				c.appendBuffer(data)
+				return
+			}
+			c.appendBuffer(data[:index+1])
+			data = data[index+1:]
+			c.parseLine()
+		case parsingBody, parsingChunkData:
+			n := int64(len(data))
+			if n > c.remaining {
+				n = c.remaining
+			}
+			data = data[n:]
+			c.remaining -= n
+			if c.remaining == 0 {
+				if c.state == parsingChunkData {
+					c.state = parsingChunkSize
+				} else {
+					c.state = parsingHeader
+				}
+			}
+		case parsingStopped:
+			return
+		}
+	}
+}
+
+// appendBuffer appends the data to the buffer of the current line, and stops the parser if it is too large.
+func (c *headerOrderConn) appendBuffer(data []byte) {
+	if len(c.buffer)+len(data) > maxHeaderBlockSize {
+		c.stop()
+		return
+	}
+	c.buffer = append(c.buffer, data...)
+}
+
+// parseLine handles a complete line stored in the buffer.
+func (c *headerOrderConn) parseLine() {
+	if c.state == parsingStopped {
+		return
+	}
+	switch c.state {
+	case parsingHeader:
+		// the header block is complete once an empty line is read
+		if !bytes.HasSuffix(c.buffer, []byte("\r\n\r\n")) && !bytes.HasSuffix(c.buffer, []byte("\n\n")) {
+			// skip the empty lines preceding a request
+			if strings.TrimSpace(string(c.buffer)) == "" {
+				c.buffer = c.buffer[:0]
+			}
+			return
+		}
+		c.parseHeaderBlock()
+	case parsingChunkSize:
+		line := strings.TrimSpace(string(c.buffer))
+		c.buffer = c.buffer[:0]
+		if line == "" {
+			// CRLF following the data of a chunk
+			return
+		}
+		if index := strings.IndexByte(line, ';'); index >= 0 {
+			line = line[:index]
+		}
+		size, err := strconv.ParseInt(strings.TrimSpace(line), 16, 64)
+		if err != nil || size < 0 {
+			c.stop()
+			return
+		}
+		if size == 0 {
+			c.state = parsingTrailer
+			return
+		}
+		c.remaining = size
+		c.state = parsingChunkData
+	case parsingTrailer:
+		line := strings.TrimSpace(string(c.buffer))
+		c.buffer = c.buffer[:0]
+		if line == "" {
+			c.state = parsingHeader
+		}
+	}
+}
+
+// parseHeaderBlock extracts the header names of the request header block stored in the buffer,
+// and prepares the parser to skip the body of the request.
+func (c *headerOrderConn) parseHeaderBlock() {
+	lines := strings.Split(strings.ReplaceAll(string(c.buffer), "\r\n", "\n"), "\n")
+	c.buffer = c.buffer[:0]
+
+	names := []string{}
+	var contentLength int64
+	chunked := false
+	upgrade := false
+	for _, line := range lines[1:] {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || name == "" || strings.TrimSpace(name) != name {
+			continue
+		}
+		names = append(names, name)
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(name) {
+		case "content-length":
+			contentLength, _ = strconv.ParseInt(value, 10, 64)
+		case "transfer-encoding":
+			chunked = strings.Contains(strings.ToLower(value), "chunked")
+		case "upgrade":
+			upgrade = true
+		}
+	}
+	if c.unanswered {
+		// the request is pipelined: the previous request may still be served
+		c.stop()
+		return
+	}
+	c.headerNames = names
+	c.unanswered = true
+
+	switch {
+	case strings.HasPrefix(lines[0], "PRI * HTTP/2"):
+		c.stop()
+	case upgrade:
+		c.state = parsingStopped
+	case chunked:
+		c.state = parsingChunkSize
+	case contentLength > 0:
+		c.remaining = contentLength
+		c.state = parsingBody
+	}
+}
+
+// stop stops the parser and clears the header names, which may be incomplete or belong to another request.
+func (c *headerOrderConn) stop() {
+	c.state = parsingStopped
+	c.buffer = nil
+	c.headerNames = nil
+}
+
+// getHeaderOrder returns the header names of the request in the order they were received,
+// or nil if they were not captured.
+func getHeaderOrder(r *http.Request) []string {
+	conn, ok := r.Context().Value(headerOrderContextKey{}).(*headerOrderConn)
+	if !ok {
+		return nil
+	}
+	return conn.lastHeaderNames()
+}
+
+// getHeaderSetHash returns a hash of the set of the header names of the request.
+// The names are lowercased and sorted so the hash does not depend on their order or their casing.
+func getHeaderSetHash(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return hashValue(strings.Join(names, ","))
+}
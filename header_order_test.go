@@ -0,0 +1,211 @@
+package fraudsdkgo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderOrderConn_Parse(t *testing.T) {
+	t.Run("Request without body", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("GET / HTTP/1.1\r\nHost: example.com\r\nUser-Agent: test\r\naccept: */*\r\n\r\n"))
+		assert.Equal(t, []string{"Host", "User-Agent", "accept"}, c.lastHeaderNames())
+	})
+
+	t.Run("Request split across reads", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("GET / HTTP/1.1\r\nHo"))
+		c.parse([]byte("st: example.com\r\nAccept: */*\r"))
+		assert.Nil(t, c.lastHeaderNames())
+		c.parse([]byte("\n\r\n"))
+		assert.Equal(t, []string{"Host", "Accept"}, c.lastHeaderNames())
+	})
+
+	t.Run("Body with Content-Length is skipped", func(t *testing.T) {
+		body := "a: b\r\n\r\n"
+		c := &headerOrderConn{}
+		c.parse([]byte(fmt.Sprintf("POST / HTTP/1.1\r\nHost: example.com\r\nContent-Length: %d\r\n\r\n%s", len(body), body)))
+		assert.Equal(t, []string{"Host", "Content-Length"}, c.lastHeaderNames())
+		c.answer()
+		c.parse([]byte("GET / HTTP/1.1\r\nAccept: */*\r\nHost: example.com\r\n\r\n"))
+		assert.Equal(t, []string{"Accept", "Host"}, c.lastHeaderNames())
+	})
+
+	t.Run("Chunked body is skipped", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("POST / HTTP/1.1\r\nHost: example.com\r\nTransfer-Encoding: chunked\r\n\r\n"))
+		c.parse([]byte("8\r\na: b\r\n\r\n\r\n0\r\nX-Trailer: value\r\n\r\n"))
+		assert.Equal(t, []string{"Host", "Transfer-Encoding"}, c.lastHeaderNames())
+		c.answer()
+		c.parse([]byte("GET / HTTP/1.1\r\nHost: example.com\r\nX-Next: 1\r\n\r\n"))
+		assert.Equal(t, []string{"Host", "X-Next"}, c.lastHeaderNames())
+	})
+
+	t.Run("Upgraded connection stops the capture", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("GET / HTTP/1.1\r\nHost: example.com\r\nUpgrade: websocket\r\n\r\n"))
+		assert.Equal(t, parsingStopped, c.state)
+		c.parse([]byte("GET / HTTP/1.1\r\nAccept: */*\r\n\r\n"))
+		assert.Equal(t, []string{"Host", "Upgrade"}, c.lastHeaderNames())
+	})
+
+	t.Run("Too large header block stops the capture", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse(make([]byte, maxHeaderBlockSize+1))
+		assert.Equal(t, parsingStopped, c.state)
+	})
+
+	t.Run("New request clears the previous names", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"))
+		c.answer()
+		c.parse([]byte("GET / HTTP/1.1\r\nAccept: */*\r\n"))
+		assert.Nil(t, c.lastHeaderNames())
+	})
+
+	t.Run("Too large header block clears the previous names", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"))
+		c.answer()
+		c.parse([]byte("GET / HTTP/1.1\r\nX-Large: "))
+		c.parse(bytes.Repeat([]byte("a"), maxHeaderBlockSize))
+		assert.Equal(t, parsingStopped, c.state)
+		assert.Nil(t, c.lastHeaderNames())
+	})
+
+	t.Run("Invalid chunk size clears the names", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("POST / HTTP/1.1\r\nHost: example.com\r\nTransfer-Encoding: chunked\r\n\r\nzz\r\n"))
+		assert.Equal(t, parsingStopped, c.state)
+		assert.Nil(t, c.lastHeaderNames())
+	})
+
+	t.Run("Pipelined requests stop the capture", func(t *testing.T) {
+		c := &headerOrderConn{}
+		c.parse([]byte("GET /a HTTP/1.1\r\nHost: example.com\r\n\r\nGET /b HTTP/1.1\r\nAccept: */*\r\n\r\n"))
+		assert.Equal(t, parsingStopped, c.state)
+		assert.Nil(t, c.lastHeaderNames())
+		c.answer()
+		c.parse([]byte("GET /c HTTP/1.1\r\nHost: example.com\r\n\r\n"))
+		assert.Nil(t, c.lastHeaderNames())
+	})
+}
+
+func TestGetHeaderSetHash(t *testing.T) {
+	h1 := http.Header{}
+	h1.Set("Accept", "*/*")
+	h1.Set("User-Agent", "test")
+	h2 := http.Header{}
+	h2.Set("user-agent", "other")
+	h2.Set("accept", "text/html")
+
+	assert.Equal(t, getHeaderSetHash(h1), getHeaderSetHash(h2))
+	h2.Set("DNT", "1")
+	assert.NotEqual(t, getHeaderSetHash(h1), getHeaderSetHash(h2))
+}
+
+func TestHeaderOrderListener(t *testing.T) {
+	c, err := NewClient("your-api-key", ClientWithHeaderFingerprint())
+	assert.Nil(t, err)
+
+	headers := make(chan *Header, 2)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, _ := c.buildHeader(r, &RequestMetadata{})
+		headers <- header
+	}))
+	srv.Listener = NewHeaderOrderListener(srv.Listener)
+	srv.Config.ConnContext = HeaderOrderConnContext
+	srv.Start()
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	fmt.Fprint(conn, "POST /login HTTP/1.1\r\nHost: example.com\r\nuser-agent: test\r\nContent-Length: 4\r\nACCEPT: */*\r\n\r\nbody")
+	resp, err := http.ReadResponse(reader, nil)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	header := <-headers
+	assert.Equal(t, "Host,user-agent,Content-Length,ACCEPT", *header.HeaderOrder)
+	assert.Equal(t, getHeaderSetHash(http.Header{"Accept": nil, "Content-Length": nil, "User-Agent": nil}), *header.HeaderSetHash)
+
+	fmt.Fprint(conn, "GET /ping HTTP/1.1\r\nAccept: */*\r\nHost: example.com\r\n\r\n")
+	resp, err = http.ReadResponse(reader, nil)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	header = <-headers
+	assert.Equal(t, "Accept,Host", *header.HeaderOrder)
+}
+
+func TestHeaderOrderListener_TLS(t *testing.T) {
+	c, err := NewClient("your-api-key", ClientWithHeaderFingerprint())
+	assert.Nil(t, err)
+
+	// the test server provides the certificate and the client trusting it
+	certSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer certSrv.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	conns := make(chan *headerOrderConn, 1)
+	headers := make(chan *Header, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header, _ := c.buildHeader(r, &RequestMetadata{})
+			headers <- header
+		}),
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			if tlsConn, ok := c.(*tls.Conn); ok {
+				conn, _ := tlsConn.NetConn().(*headerOrderConn)
+				conns <- conn
+			}
+			return HeaderOrderConnContext(ctx, c)
+		},
+		TLSConfig: &tls.Config{Certificates: certSrv.TLS.Certificates},
+	}
+	go func() { _ = srv.ServeTLS(NewHeaderOrderListener(ln), "", "") }()
+	defer srv.Close()
+
+	resp, err := certSrv.Client().Get("https://" + ln.Addr().String() + "/ping")
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	header := <-headers
+	assert.Nil(t, header.HeaderOrder)
+	conn := <-conns
+	assert.NotNil(t, conn)
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	assert.Equal(t, parsingStopped, conn.state)
+	assert.Nil(t, conn.buffer)
+}
+
+func TestWithHeaderFingerprint(t *testing.T) {
+	request := setupRequest()
+
+	c, err := NewClient("your-api-key")
+	assert.Nil(t, err)
+	header, err := c.buildHeader(request, &RequestMetadata{})
+	assert.Nil(t, err)
+	assert.Nil(t, header.HeaderOrder)
+	assert.Nil(t, header.HeaderSetHash)
+
+	c, err = NewClient("your-api-key", ClientWithHeaderFingerprint())
+	assert.Nil(t, err)
+	header, err = c.buildHeader(request, &RequestMetadata{})
+	assert.Nil(t, err)
+	assert.Nil(t, header.HeaderOrder)
+	assert.NotNil(t, header.HeaderSetHash)
+	assert.Equal(t, getHeaderSetHash(request.Header), *header.HeaderSetHash)
+}
@@ -46,6 +46,22 @@ func NewLoginEvent(account string, status LoginStatus, options ...LoginOption) *
 	return event
 }
 
+// Check returns the problems found in the fields of the [LoginEvent] (see [Checker]).
+func (e *LoginEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	checkOneOf(&pc, "status", e.Status, Failed, Succeeded)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	pc.authentication("authentication", e.Authentication)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [LoginEvent] (see [Describer]).
+func (e *LoginEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
 // Validate is used to construct the [LoginRequestPayload] based on the information stored in the [LoginEvent] structure
 // and performs the validation request to the Account Protect API.
 // An error may be returned in case of error when performing the request.
@@ -0,0 +1,326 @@
+package fraudsdkgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxAccountBodySize is the maximum number of bytes of the request body read to extract the account.
+const maxAccountBodySize = 1 << 20
+
+// AccountExtractor describes the signature of the function returning the account of the login request.
+// The body is the beginning of the request body, the request body itself remains readable by the handler.
+type AccountExtractor func(r *http.Request, body []byte) string
+
+// LoginCollectOption describes the functional option signature to customize the login collect middleware.
+type LoginCollectOption func(*loginCollectConfig)
+
+// loginCollectConfig stores the settings of the login collect middleware.
+type loginCollectConfig struct {
+	errorHook        func(r *http.Request, err error)
+	failureRedirects []string
+	options          func(r *http.Request) []LoginOption
+	successRedirects []string
+}
+
+// loginStatusContextKey is the key used to store the [LoginStatus] marker in the context of the request.
+type loginStatusContextKey struct{}
+
+// loginStatusMarker stores the [LoginStatus] set by the handler with [SetLoginStatus].
+type loginStatusMarker struct {
+	status LoginStatus
+}
+
+// AccountFromForm returns an [AccountExtractor] reading the account from the field of
+// an `application/x-www-form-urlencoded` body, or from the query string if the field is not in the body.
+func AccountFromForm(field string) AccountExtractor {
+	return func(r *http.Request, body []byte) string {
+		if values, err := url.ParseQuery(string(body)); err == nil && values.Has(field) {
+			return values.Get(field)
+		}
+		return r.URL.Query().Get(field)
+	}
+}
+
+// AccountFromJSON returns an [AccountExtractor] reading the account from the string field of a JSON body.
+// The nested fields are separated by dots, e.g. `user.email`.
+func AccountFromJSON(field string) AccountExtractor {
+	return func(r *http.Request, body []byte) string {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return ""
+		}
+		for _, key := range strings.Split(field, ".") {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return ""
+			}
+			value = object[key]
+		}
+		account, _ := value.(string)
+		return account
+	}
+}
+
+// AccountFromBody returns an [AccountExtractor] reading the account from the field of the body,
+// with [AccountFromJSON] or [AccountFromForm] depending on the `Content-Type` of the request.
+func AccountFromBody(field string) AccountExtractor {
+	fromForm := AccountFromForm(field)
+	fromJSON := AccountFromJSON(field)
+	return func(r *http.Request, body []byte) string {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return fromJSON(r, body)
+		}
+		return fromForm(r, body)
+	}
+}
+
+// LoginCollectWithSuccessRedirect is a functional option to consider the login as [Succeeded] when the handler
+// redirects to a location starting with one of the targets, e.g. `/account`.
+func LoginCollectWithSuccessRedirect(targets ...string) LoginCollectOption {
+	return func(cfg *loginCollectConfig) {
+		cfg.successRedirects = append(cfg.successRedirects, targets...)
+	}
+}
+
+// LoginCollectWithFailureRedirect is a functional option to consider the login as [Failed] when the handler
+// redirects to a location starting with one of the targets, e.g. `/login?error`.
+func LoginCollectWithFailureRedirect(targets ...string) LoginCollectOption {
+	return func(cfg *loginCollectConfig) {
+		cfg.failureRedirects = append(cfg.failureRedirects, targets...)
+	}
+}
+
+// LoginCollectWithLoginOptions is a functional option to set the function returning the options
+// of the [LoginEvent] sent for the request, e.g. to set the [User] or the [Authentication].
+func LoginCollectWithLoginOptions(options func(r *http.Request) []LoginOption) LoginCollectOption {
+	return func(cfg *loginCollectConfig) {
+		cfg.options = options
+	}
+}
+
+// LoginCollectWithErrorHook is a functional option to set the function called when the account cannot be
+// extracted or the collect fails, e.g. to log the error.
+func LoginCollectWithErrorHook(hook func(r *http.Request, err error)) LoginCollectOption {
+	return func(cfg *loginCollectConfig) {
+		cfg.errorHook = hook
+	}
+}
+
+// SetLoginStatus marks the outcome of the login handled by the login collect middleware
+// (see [NewLoginCollectMiddleware]). It takes precedence over the status code and the redirect of the response.
+// It has no effect if the request is not served by the middleware.
+func SetLoginStatus(r *http.Request, status LoginStatus) {
+	if marker, ok := r.Context().Value(loginStatusContextKey{}).(*loginStatusMarker); ok {
+		marker.status = status
+	}
+}
+
+// NewLoginCollectMiddleware returns a net/http middleware sending the [LoginEvent] to the Account Protect API
+// with [Client.Collect] once the login handler has served the request.
+//
+// The account is extracted from the request with the [AccountExtractor] (see [AccountFromBody]).
+// The [LoginStatus] is inferred, by order of precedence:
+//   - from the marker set by the handler with [SetLoginStatus]
+//   - from the redirect of the response (see [LoginCollectWithSuccessRedirect] and [LoginCollectWithFailureRedirect])
+//   - from the status code of the response: [Succeeded] for 2xx and 3xx, [Failed] for 4xx
+//
+// Warning: a handler rendering the login form again with a 2xx status code and an error message, instead of
+// responding with a 4xx status code or redirecting, reports the failed logins as [Succeeded].
+// Such handlers must call [SetLoginStatus] on every outcome.
+//
+// No event is sent when the status cannot be inferred, e.g. for 5xx status codes or, when redirect targets
+// are configured, for the redirects to other locations.
+//
+// The [Header] is built once the handler returns, and the event is sent in the background so the response
+// is not delayed by the call to the Account Protect API. The error hook may be called from the background goroutine.
+func NewLoginCollectMiddleware(c *Client, account AccountExtractor, options ...LoginCollectOption) func(http.Handler) http.Handler {
+	cfg := &loginCollectConfig{}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := peekBody(r)
+			marker := &loginStatusMarker{}
+			r = r.WithContext(context.WithValue(r.Context(), loginStatusContextKey{}, marker))
+			recorder := &statusRecorder{ResponseWriter: w}
+
+			next.ServeHTTP(recorder, r)
+
+			status, ok := cfg.inferStatus(marker, recorder)
+			if !ok {
+				return
+			}
+			accountValue := account(r, body)
+			if accountValue == "" {
+				cfg.reportError(r, ErrAccountNotFound)
+				return
+			}
+			var loginOptions []LoginOption
+			if cfg.options != nil {
+				loginOptions = cfg.options(r)
+			}
+			event := NewLoginEvent(accountValue, status, loginOptions...)
+			if problems := c.checkEvent(event); len(problems) > 0 {
+				cfg.reportError(r, ErrInvalidEvent)
+				return
+			}
+			// the header is built before the connection serves the next request,
+			// which would replace the header order captured for the connection
+			header, err := c.buildHeader(r, &RequestMetadata{})
+			if err != nil {
+				cfg.reportError(r, fmt.Errorf("fail to extract request fingerprint: %w", err))
+				return
+			}
+
+			// the request context is canceled once the handler returns
+			ctx := detachedContext{Context: r.Context()}
+			go func() {
+				if _, err := c.collectEvent(newMetadataRequest(ctx, header), event, header); err != nil {
+					cfg.reportError(r, err)
+				}
+			}()
+		})
+	}
+}
+
+// inferStatus returns the [LoginStatus] of the login, and whether it can be inferred.
+func (cfg *loginCollectConfig) inferStatus(marker *loginStatusMarker, recorder *statusRecorder) (LoginStatus, bool) {
+	if marker.status != "" {
+		return marker.status, true
+	}
+	code := recorder.statusCode()
+	if code >= 300 && code < 400 {
+		location := recorder.Header().Get("Location")
+		if hasAnyPrefix(location, cfg.failureRedirects) {
+			return Failed, true
+		}
+		if hasAnyPrefix(location, cfg.successRedirects) {
+			return Succeeded, true
+		}
+		if len(cfg.failureRedirects) > 0 || len(cfg.successRedirects) > 0 {
+			return "", false
+		}
+	}
+	switch {
+	case code >= 200 && code < 400:
+		return Succeeded, true
+	case code >= 400 && code < 500:
+		return Failed, true
+	}
+	return "", false
+}
+
+// reportError calls the error hook if any.
+func (cfg *loginCollectConfig) reportError(r *http.Request, err error) {
+	if cfg.errorHook != nil {
+		cfg.errorHook(r, err)
+	}
+}
+
+// peekBody returns the beginning of the request body and restores the body for the next handlers.
+func peekBody(r *http.Request) []byte {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(r.Body, maxAccountBodySize))
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+	return body
+}
+
+// detachedContext is a [context.Context] keeping the values of its parent, without its deadline and cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+// Deadline returns no deadline.
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done returns a nil channel: the context is never canceled.
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err returns nil: the context is never canceled.
+func (detachedContext) Err() error {
+	return nil
+}
+
+// readCloser combines a [io.Reader] and the [io.Closer] of the original body.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// hasAnyPrefix returns whether the value is not empty and starts with one of the prefixes.
+func hasAnyPrefix(value string, prefixes []string) bool {
+	if value == "" {
+		return false
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusRecorder is a [http.ResponseWriter] recording the status code written by the handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+// WriteHeader records the status code and writes it to the underlying [http.ResponseWriter].
+func (sr *statusRecorder) WriteHeader(code int) {
+	if sr.code == 0 {
+		sr.code = code
+	}
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes the data to the underlying [http.ResponseWriter], with an implicit `200 OK` status code.
+func (sr *statusRecorder) Write(data []byte) (int, error) {
+	if sr.code == 0 {
+		sr.code = http.StatusOK
+	}
+	return sr.ResponseWriter.Write(data)
+}
+
+// Flush sends the buffered data to the client if the underlying [http.ResponseWriter] supports it.
+func (sr *statusRecorder) Flush() {
+	if sr.code == 0 {
+		sr.code = http.StatusOK
+	}
+	if flusher, ok := sr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], used by [http.ResponseController].
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+// statusCode returns the status code written by the handler, `200 OK` if the handler did not write any.
+func (sr *statusRecorder) statusCode() int {
+	if sr.code == 0 {
+		return http.StatusOK
+	}
+	return sr.code
+}
@@ -0,0 +1,272 @@
+package fraudsdkgo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupLoginRequest(contentType string, body string) *http.Request {
+	r := setupRequest()
+	r.Method = http.MethodPost
+	r.Header.Set("Content-Type", contentType)
+	r.Body = io.NopCloser(strings.NewReader(body))
+	return r
+}
+
+// setupCollectServer returns a test server forwarding the received login payloads to the channel.
+// The server waits for the release channel, if not nil, before responding.
+func setupCollectServer(release chan struct{}) (*httptest.Server, chan *LoginRequestPayload) {
+	received := make(chan *LoginRequestPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := &LoginRequestPayload{}
+		_ = json.NewDecoder(r.Body).Decode(payload)
+		if release != nil {
+			<-release
+		}
+		received <- payload
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return server, received
+}
+
+// receivePayload returns the payload sent in the background by the middleware, or nil if none is sent.
+func receivePayload(received chan *LoginRequestPayload) *LoginRequestPayload {
+	select {
+	case payload := <-received:
+		return payload
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestAccountFromBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       string
+		contentType string
+		body        string
+		want        string
+	}{
+		{"Form field", "username", "application/x-www-form-urlencoded", "username=john%40example.com&password=secret", "john@example.com"},
+		{"Missing form field", "username", "application/x-www-form-urlencoded", "password=secret", ""},
+		{"JSON field", "username", "application/json; charset=utf-8", `{"username":"john","password":"secret"}`, "john"},
+		{"Nested JSON field", "user.email", "application/vnd.api+json", `{"user":{"email":"john@example.com"}}`, "john@example.com"},
+		{"Non-string JSON field", "username", "application/json", `{"username":42}`, ""},
+		{"Invalid JSON", "username", "application/json", `username=john`, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := setupLoginRequest(tc.contentType, tc.body)
+			assert.Equal(t, tc.want, AccountFromBody(tc.field)(r, []byte(tc.body)))
+		})
+	}
+}
+
+func TestLoginCollectMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []LoginCollectOption
+		handler http.HandlerFunc
+		want    LoginStatus
+	}{
+		{"Implicit OK", nil, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("welcome"))
+		}, Succeeded},
+		{"Unauthorized", nil, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}, Failed},
+		{"Server error", nil, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}, ""},
+		{"Redirect", nil, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/account", http.StatusFound)
+		}, Succeeded},
+		{"Failure redirect", []LoginCollectOption{LoginCollectWithFailureRedirect("/login?error")}, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/login?error=credentials", http.StatusSeeOther)
+		}, Failed},
+		{"Success redirect", []LoginCollectOption{LoginCollectWithSuccessRedirect("/account")}, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/account/profile", http.StatusSeeOther)
+		}, Succeeded},
+		{"Unknown redirect", []LoginCollectOption{LoginCollectWithSuccessRedirect("/account")}, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/maintenance", http.StatusSeeOther)
+		}, ""},
+		{"Context marker", nil, func(w http.ResponseWriter, r *http.Request) {
+			SetLoginStatus(r, Failed)
+			_, _ = w.Write([]byte("invalid credentials"))
+		}, Failed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, received := setupCollectServer(nil)
+			defer server.Close()
+			c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+			assert.Nil(t, err)
+
+			var body []byte
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				tc.handler(w, r)
+			})
+
+			w := httptest.NewRecorder()
+			middleware := NewLoginCollectMiddleware(c, AccountFromBody("username"), tc.options...)
+			middleware(handler).ServeHTTP(w, setupLoginRequest("application/x-www-form-urlencoded", "username=john&password=secret"))
+
+			assert.Equal(t, "username=john&password=secret", string(body))
+			sent := receivePayload(received)
+			if tc.want == "" {
+				assert.Nil(t, sent)
+				return
+			}
+			if assert.NotNil(t, sent) {
+				assert.Equal(t, "john", sent.Account)
+				assert.Equal(t, tc.want, sent.Status)
+			}
+		})
+	}
+}
+
+func TestLoginCollectMiddlewareOptions(t *testing.T) {
+	server, received := setupCollectServer(nil)
+	defer server.Close()
+	c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+	assert.Nil(t, err)
+
+	t.Run("Login options", func(t *testing.T) {
+		options := func(r *http.Request) []LoginOption {
+			return []LoginOption{LoginWithUser(User{ID: "123456"})}
+		}
+
+		w := httptest.NewRecorder()
+		middleware := NewLoginCollectMiddleware(c, AccountFromJSON("username"), LoginCollectWithLoginOptions(options))
+		middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, setupLoginRequest("application/json", `{"username":"john"}`))
+
+		if sent := receivePayload(received); assert.NotNil(t, sent) && assert.NotNil(t, sent.User) {
+			assert.Equal(t, "123456", sent.User.ID)
+		}
+	})
+
+	t.Run("Account not found", func(t *testing.T) {
+		var reported error
+		hook := func(r *http.Request, err error) {
+			reported = err
+		}
+
+		w := httptest.NewRecorder()
+		middleware := NewLoginCollectMiddleware(c, AccountFromForm("username"), LoginCollectWithErrorHook(hook))
+		middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, setupLoginRequest("application/x-www-form-urlencoded", "email=john"))
+
+		assert.ErrorIs(t, reported, ErrAccountNotFound)
+		assert.Nil(t, receivePayload(received))
+	})
+}
+
+func TestLoginCollectMiddleware_Background(t *testing.T) {
+	release := make(chan struct{})
+	server, received := setupCollectServer(release)
+	defer server.Close()
+	c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+	assert.Nil(t, err)
+
+	reported := make(chan error, 1)
+	hook := func(r *http.Request, err error) {
+		reported <- err
+	}
+
+	w := httptest.NewRecorder()
+	middleware := NewLoginCollectMiddleware(c, AccountFromForm("username"), LoginCollectWithErrorHook(hook))
+	ctx, cancel := context.WithCancel(context.Background())
+	r := setupLoginRequest("application/x-www-form-urlencoded", "username=john").WithContext(ctx)
+	middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})).ServeHTTP(w, r)
+
+	// the response is served before the Account Protect API responds, and the request context may be canceled
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	cancel()
+	close(release)
+
+	if sent := receivePayload(received); assert.NotNil(t, sent) {
+		assert.Equal(t, "john", sent.Account)
+		assert.Equal(t, Failed, sent.Status)
+	}
+	select {
+	case err := <-reported:
+		assert.Nil(t, err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func ExampleNewLoginCollectMiddleware() {
+	c, err := NewClient("your-api-key")
+	if err != nil {
+		return
+	}
+
+	middleware := NewLoginCollectMiddleware(c, AccountFromBody("username"),
+		LoginCollectWithFailureRedirect("/login?error"),
+		LoginCollectWithErrorHook(func(r *http.Request, err error) {
+			fmt.Println("fail to collect the login:", err)
+		}),
+	)
+
+	http.Handle("/login", middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("password") == "" {
+			SetLoginStatus(r, Failed)
+		}
+		http.Redirect(w, r, "/account", http.StatusSeeOther)
+	})))
+}
+
+func TestLoginCollectMiddleware_KeepAlive(t *testing.T) {
+	release := make(chan struct{})
+	server, received := setupCollectServer(release)
+	defer server.Close()
+	c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL), ClientWithHeaderFingerprint())
+	assert.Nil(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/login", NewLoginCollectMiddleware(c, AccountFromForm("username"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})))
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {})
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Listener = NewHeaderOrderListener(srv.Listener)
+	srv.Config.ConnContext = HeaderOrderConnContext
+	srv.Start()
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	fmt.Fprint(conn, "POST /login HTTP/1.1\r\nHost: example.com\r\nContent-Type: application/x-www-form-urlencoded\r\nContent-Length: 13\r\n\r\nusername=john")
+	resp, err := http.ReadResponse(reader, nil)
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	// the next request on the connection is served before the login is sent
+	fmt.Fprint(conn, "GET /ping HTTP/1.1\r\nX-Next: 1\r\nHost: example.com\r\n\r\n")
+	resp, err = http.ReadResponse(reader, nil)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	close(release)
+
+	if sent := receivePayload(received); assert.NotNil(t, sent) && assert.NotNil(t, sent.Header.HeaderOrder) {
+		assert.Equal(t, "Host,Content-Type,Content-Length", *sent.Header.HeaderOrder)
+	}
+}
@@ -0,0 +1,151 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// LoyaltyOption describes the functional option signature to customize the [LoyaltyEvent] behavior.
+type LoyaltyOption func(*LoyaltyEvent)
+
+// LoyaltyWithProgramID is a functional option to set the identifier of the loyalty program.
+func LoyaltyWithProgramID(programID string) LoyaltyOption {
+	return func(e *LoyaltyEvent) {
+		e.ProgramID = &programID
+	}
+}
+
+// LoyaltyWithRecipientAccount is a functional option to set the account receiving the points of a [LoyaltyTransfer].
+func LoyaltyWithRecipientAccount(recipientAccount string) LoyaltyOption {
+	return func(e *LoyaltyEvent) {
+		e.RecipientAccount = &recipientAccount
+	}
+}
+
+// LoyaltyWithSession is a functional option to set the [Session] field.
+func LoyaltyWithSession(session Session) LoyaltyOption {
+	return func(e *LoyaltyEvent) {
+		e.Session = &session
+	}
+}
+
+// LoyaltyWithUser is a functional option to set the [User] field.
+func LoyaltyWithUser(user User) LoyaltyOption {
+	return func(e *LoyaltyEvent) {
+		e.User = &user
+	}
+}
+
+// LoyaltyWithValue is a functional option to set the monetary value of the loyalty points.
+func LoyaltyWithValue(value MonetaryValue) LoyaltyOption {
+	return func(e *LoyaltyEvent) {
+		e.Value = &value
+	}
+}
+
+// NewLoyaltyEvent instantiates a new [LoyaltyEvent] that implements the [Event] interface.
+func NewLoyaltyEvent(account string, operation LoyaltyOperation, points int, options ...LoyaltyOption) *LoyaltyEvent {
+	event := &LoyaltyEvent{
+		Account:   account,
+		Action:    Loyalty,
+		Operation: operation,
+		Points:    points,
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(event)
+	}
+
+	return event
+}
+
+// Check returns the problems found in the fields of the [LoyaltyEvent] (see [Checker]).
+func (e *LoyaltyEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	checkOneOf(&pc, "operation", e.Operation, LoyaltyRedemption, LoyaltyTransfer)
+	pc.positive("points", e.Points)
+	pc.monetaryValue("value", e.Value)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [LoyaltyEvent] (see [Describer]).
+func (e *LoyaltyEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
+// buildRequestPayload is used to construct the [LoyaltyRequestPayload] based on the information stored
+// in the [LoyaltyEvent] structure.
+func (e *LoyaltyEvent) buildRequestPayload(module *Module, header *Header) *LoyaltyRequestPayload {
+	return &LoyaltyRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: e.Account,
+			Header:  *header,
+			Module:  *module,
+		},
+		Operation:        e.Operation,
+		Points:           e.Points,
+		ProgramID:        e.ProgramID,
+		RecipientAccount: e.RecipientAccount,
+		Session:          e.Session,
+		User:             e.User,
+		Value:            e.Value,
+	}
+}
+
+// Validate is used to construct the [LoyaltyRequestPayload] based on the information stored
+// in the [LoyaltyEvent] structure and performs the validation request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *LoyaltyEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/validate/loyalty", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		resp := &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+			},
+		}
+		if errors.Is(err, ErrRequestTimeout) {
+			resp.Status = Timeout
+		} else {
+			resp.Status = Failure
+		}
+		return resp, fmt.Errorf("fail to validate loyalty request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		return handleErrorResponse(responsePayload), nil
+	}
+	resp, err := decodeResponse[ResponsePayload](responsePayload)
+	if err != nil {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Failure,
+			},
+		}, err
+	}
+	resp.Status = OK
+	return resp, nil
+}
+
+// Collect is used to construct the [LoyaltyRequestPayload] based on the information stored
+// in the [LoyaltyEvent] structure and performs the enrichment request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *LoyaltyEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/collect/loyalty", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("fail to collect loyalty request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		responsePayload := handleErrorResponse(responsePayload)
+		return &responsePayload.ErrorResponsePayload, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,64 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoyaltyWithProgramID(t *testing.T) {
+	event := NewLoyaltyEvent("test-account", LoyaltyRedemption, 500, LoyaltyWithProgramID("gold"))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.ProgramID)
+	assert.Equal(t, "gold", *event.ProgramID)
+}
+
+func TestLoyaltyWithRecipientAccount(t *testing.T) {
+	event := NewLoyaltyEvent("test-account", LoyaltyTransfer, 500, LoyaltyWithRecipientAccount("recipient-account"))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.RecipientAccount)
+	assert.Equal(t, "recipient-account", *event.RecipientAccount)
+}
+
+func TestLoyaltyWithSession(t *testing.T) {
+	sessionID := "123456"
+	session := Session{
+		ID: &sessionID,
+	}
+
+	event := NewLoyaltyEvent("test-account", LoyaltyRedemption, 500, LoyaltyWithSession(session))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Session)
+	assert.Equal(t, sessionID, *event.Session.ID)
+}
+
+func TestLoyaltyWithUser(t *testing.T) {
+	event := NewLoyaltyEvent("test-account", LoyaltyRedemption, 500, LoyaltyWithUser(User{ID: "123456"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.User)
+	assert.Equal(t, "123456", event.User.ID)
+}
+
+func TestLoyaltyWithValue(t *testing.T) {
+	event := NewLoyaltyEvent("test-account", LoyaltyRedemption, 500, LoyaltyWithValue(MonetaryValue{Amount: 500, Currency: "EUR"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Value)
+	assert.Equal(t, int64(500), event.Value.Amount)
+}
+
+func TestNewLoyaltyEvent(t *testing.T) {
+	event := NewLoyaltyEvent("test-account", LoyaltyTransfer, 500)
+	assert.NotNil(t, event)
+	assert.Equal(t, "test-account", event.Account)
+	assert.Equal(t, Loyalty, event.Action)
+	assert.Equal(t, LoyaltyTransfer, event.Operation)
+	assert.Equal(t, 500, event.Points)
+}
+
+func ExampleLoyaltyWithRecipientAccount() {
+	event := NewLoyaltyEvent("test-account", LoyaltyTransfer, 500, LoyaltyWithRecipientAccount("recipient-account"))
+
+	fmt.Println(event.Operation, event.Points, *event.RecipientAccount)
+	// Output: transfer 500 recipient-account
+}
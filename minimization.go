@@ -0,0 +1,252 @@
+package fraudsdkgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MinimizationProfile describes the fields of the request payloads that can be shared with
+// the Account Protect API, e.g. to comply with the regulation of a jurisdiction.
+//
+// The fields are identified by their JSON path in the payload: `header.<field>`, `session.<field>`,
+// `user.<field>` or `user.address.<field>` (e.g. `header.from` or `user.address.line1`).
+//
+// When a section (`header`, `session`, `user` or `user.address`) has allowed fields, only these fields
+// are kept in the section. The denied fields are always removed. The other sections are left untouched.
+type MinimizationProfile struct {
+	Name              string
+	AllowedFields     []string
+	DeniedFields      []string
+	StripQueryStrings bool
+}
+
+// minimizationSections lists the sections of the payloads on which a [MinimizationProfile] applies,
+// with the structure describing their fields.
+var minimizationSections = map[string]reflect.Type{
+	"header":       reflect.TypeOf(Header{}),
+	"session":      reflect.TypeOf(Session{}),
+	"user":         reflect.TypeOf(User{}),
+	"user.address": reflect.TypeOf(Address{}),
+}
+
+// minimizationQueryFields lists the fields from which the query string is removed
+// when StripQueryStrings is set.
+var minimizationQueryFields = []string{"header.referer", "header.request"}
+
+type minimizationProfileKey struct{}
+
+// ContextWithMinimizationProfile returns a copy of the context that carries the [MinimizationProfile].
+// The requests performed with this context use this profile instead of the one of the [Client].
+func ContextWithMinimizationProfile(ctx context.Context, profile MinimizationProfile) context.Context {
+	return context.WithValue(ctx, minimizationProfileKey{}, &profile)
+}
+
+// getMinimizationProfile returns the [MinimizationProfile] of the context if any,
+// or the one of the [Client] otherwise.
+func (c *Client) getMinimizationProfile(ctx context.Context) *MinimizationProfile {
+	if profile, ok := ctx.Value(minimizationProfileKey{}).(*MinimizationProfile); ok {
+		return profile
+	}
+	return c.MinimizationProfile
+}
+
+// validate returns an error wrapping [ErrWrongMinimizationProfileValue] if a field of the profile is unknown.
+func (p *MinimizationProfile) validate() error {
+	for _, path := range append(append([]string{}, p.AllowedFields...), p.DeniedFields...) {
+		section, field := splitMinimizationPath(path)
+		structure, ok := minimizationSections[section]
+		if !ok || !hasJSONField(structure, field) {
+			return fmt.Errorf("%w: unknown field %q in profile %q", ErrWrongMinimizationProfileValue, path, p.Name)
+		}
+	}
+	return nil
+}
+
+// apply removes the fields that are not allowed by the profile from the JSON-encoded payload.
+func (p *MinimizationProfile) apply(body []byte) ([]byte, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	payload, err := decodeMinimizationPayload(body)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := p.allowedFieldsBySection()
+	for _, path := range p.DeniedFields {
+		section, field := splitMinimizationPath(path)
+		if object := getMinimizationSection(payload, section); object != nil {
+			delete(object, field)
+		}
+	}
+	for section, fields := range allowed {
+		object := getMinimizationSection(payload, section)
+		for field := range object {
+			if !fields[field] {
+				delete(object, field)
+			}
+		}
+	}
+	if p.StripQueryStrings {
+		for _, path := range minimizationQueryFields {
+			section, field := splitMinimizationPath(path)
+			if value, ok := getMinimizationSection(payload, section)[field].(string); ok {
+				getMinimizationSection(payload, section)[field] = stripQueryString(value)
+			}
+		}
+	}
+	return json.Marshal(payload)
+}
+
+// check returns the list of the fields of the JSON-encoded payload that are not allowed by the profile.
+func (p *MinimizationProfile) check(body []byte) ([]string, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	payload, err := decodeMinimizationPayload(body)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := []string{}
+	for _, path := range p.DeniedFields {
+		section, field := splitMinimizationPath(path)
+		if _, ok := getMinimizationSection(payload, section)[field]; ok {
+			violations = append(violations, path)
+		}
+	}
+	for section, fields := range p.allowedFieldsBySection() {
+		for field := range getMinimizationSection(payload, section) {
+			if !fields[field] {
+				violations = append(violations, section+"."+field)
+			}
+		}
+	}
+	if p.StripQueryStrings {
+		for _, path := range minimizationQueryFields {
+			section, field := splitMinimizationPath(path)
+			if value, ok := getMinimizationSection(payload, section)[field].(string); ok && stripQueryString(value) != value {
+				violations = append(violations, path)
+			}
+		}
+	}
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// allowedFieldsBySection returns the allowed fields grouped by section.
+// The `address` field of the `user` section is implicitly allowed when fields of the `user.address` section are allowed.
+func (p *MinimizationProfile) allowedFieldsBySection() map[string]map[string]bool {
+	allowed := map[string]map[string]bool{}
+	for _, path := range p.AllowedFields {
+		section, field := splitMinimizationPath(path)
+		if allowed[section] == nil {
+			allowed[section] = map[string]bool{}
+		}
+		allowed[section][field] = true
+	}
+	if allowed["user.address"] != nil && allowed["user"] != nil {
+		allowed["user"]["address"] = true
+	}
+	return allowed
+}
+
+// CheckMinimization returns an error wrapping [ErrMinimizationViolation] that lists the fields of the payload
+// that are not allowed by the [MinimizationProfile].
+// The payload may be a request payload (e.g. [LoginRequestPayload]) or its JSON encoding.
+func CheckMinimization(profile MinimizationProfile, payload interface{}) error {
+	var body []byte
+	switch p := payload.(type) {
+	case []byte:
+		body = p
+	case json.RawMessage:
+		body = p
+	default:
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("fail to marshal request payload: %w", err)
+		}
+	}
+	violations, err := profile.check(body)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%w: profile %q forbids %s", ErrMinimizationViolation, profile.Name, strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// TestingT is the subset of [testing.TB] used by [AssertMinimization].
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertMinimization is a test helper that reports an error to t if the payload does not comply
+// with the [MinimizationProfile] (see [CheckMinimization]). It returns true if the payload complies.
+func AssertMinimization(t TestingT, profile MinimizationProfile, payload interface{}) bool {
+	t.Helper()
+	if err := CheckMinimization(profile, payload); err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+	return true
+}
+
+// decodeMinimizationPayload decodes the JSON-encoded payload while keeping the numbers unchanged.
+func decodeMinimizationPayload(body []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var payload map[string]interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("fail to decode request payload: %w", err)
+	}
+	return payload, nil
+}
+
+// getMinimizationSection returns the JSON object of the section in the payload, or nil if it is missing.
+func getMinimizationSection(payload map[string]interface{}, section string) map[string]interface{} {
+	object := payload
+	for _, key := range strings.Split(section, ".") {
+		child, ok := object[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		object = child
+	}
+	return object
+}
+
+// splitMinimizationPath splits the path of a field into its section and its name.
+func splitMinimizationPath(path string) (string, string) {
+	index := strings.LastIndex(path, ".")
+	if index < 0 {
+		return "", path
+	}
+	return path[:index], path[index+1:]
+}
+
+// hasJSONField returns true if the structure has a field encoded with the given JSON name.
+func hasJSONField(structure reflect.Type, name string) bool {
+	for i := 0; i < structure.NumField(); i++ {
+		tag := strings.Split(structure.Field(i).Tag.Get("json"), ",")[0]
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// stripQueryString removes the query string and the fragment of the URL.
+func stripQueryString(value string) string {
+	if index := strings.IndexAny(value, "?#"); index >= 0 {
+		return value[:index]
+	}
+	return value
+}
@@ -0,0 +1,171 @@
+package fraudsdkgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTestingT struct {
+	errors []string
+}
+
+func (m *mockTestingT) Helper() {}
+
+func (m *mockTestingT) Errorf(format string, args ...interface{}) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func setupMinimizationPayload() *LoginRequestPayload {
+	sessionID := "123456"
+	createdAt := "1970-01-01T00:00:00Z"
+	user := setupPersonalUser()
+	return &LoginRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: "test-account",
+			Header: Header{
+				Addr:    "192.168.1.1",
+				From:    "john.doe@example.com",
+				Port:    443,
+				Referer: "https://example.com/login?token=secret#step",
+				Request: "/login?email=john.doe@example.com",
+			},
+		},
+		Session: &Session{ID: &sessionID, CreatedAt: &createdAt},
+		Status:  Succeeded,
+		User:    &user,
+	}
+}
+
+func TestMinimizationProfile_Apply(t *testing.T) {
+	profile := MinimizationProfile{
+		Name:              "strict",
+		AllowedFields:     []string{"user.id", "user.address.city", "session.id"},
+		DeniedFields:      []string{"header.from"},
+		StripQueryStrings: true,
+	}
+	body, _ := json.Marshal(setupMinimizationPayload())
+
+	minimized, err := profile.apply(body)
+	assert.Nil(t, err)
+
+	var payload map[string]interface{}
+	assert.Nil(t, json.Unmarshal(minimized, &payload))
+	header := payload["header"].(map[string]interface{})
+	assert.NotContains(t, header, "from")
+	assert.Equal(t, "192.168.1.1", header["addr"])
+	assert.Equal(t, float64(443), header["port"])
+	assert.Equal(t, "https://example.com/login", header["referer"])
+	assert.Equal(t, "/login", header["request"])
+	assert.Equal(t, map[string]interface{}{"id": "123456"}, payload["session"])
+	assert.Equal(t, map[string]interface{}{"id": "123456", "address": map[string]interface{}{"city": "Paris"}}, payload["user"])
+	assert.Equal(t, "succeeded", payload["status"])
+	assert.Nil(t, CheckMinimization(profile, minimized))
+}
+
+func TestMinimizationProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile MinimizationProfile
+		valid   bool
+	}{
+		{"Known fields", MinimizationProfile{AllowedFields: []string{"user.id"}, DeniedFields: []string{"header.referer", "user.address.line1"}}, true},
+		{"Unknown section", MinimizationProfile{DeniedFields: []string{"module.name"}}, false},
+		{"Unknown field", MinimizationProfile{DeniedFields: []string{"header.cookie"}}, false},
+		{"Field without section", MinimizationProfile{AllowedFields: []string{"email"}}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.profile.validate()
+			if tc.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrWrongMinimizationProfileValue)
+			}
+		})
+	}
+}
+
+func TestCheckMinimization(t *testing.T) {
+	profile := MinimizationProfile{
+		Name:              "eu",
+		DeniedFields:      []string{"header.from", "user.address.line1"},
+		StripQueryStrings: true,
+	}
+
+	err := CheckMinimization(profile, setupMinimizationPayload())
+	assert.ErrorIs(t, err, ErrMinimizationViolation)
+	assert.Contains(t, err.Error(), "header.from, header.referer, header.request, user.address.line1")
+
+	mockT := &mockTestingT{}
+	assert.False(t, AssertMinimization(mockT, profile, setupMinimizationPayload()))
+	assert.Len(t, mockT.errors, 1)
+
+	mockT = &mockTestingT{}
+	assert.True(t, AssertMinimization(mockT, MinimizationProfile{}, setupMinimizationPayload()))
+	assert.Empty(t, mockT.errors)
+}
+
+func TestWithMinimizationProfile(t *testing.T) {
+	t.Run("Applies the profile of the client or of the call", func(t *testing.T) {
+		var bodies [][]byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, body)
+			_, _ = w.Write([]byte(`{"action":"allow"}`))
+		}))
+		defer server.Close()
+
+		clientProfile := MinimizationProfile{Name: "default", DeniedFields: []string{"header.from"}}
+		callProfile := MinimizationProfile{Name: "strict", AllowedFields: []string{"user.id"}, DeniedFields: []string{"header.from", "header.referer"}}
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL), ClientWithMinimizationProfile(clientProfile))
+		assert.Nil(t, err)
+
+		user := setupPersonalUser()
+		request := setupRequest()
+		request.Header.Set("From", "john.doe@example.com")
+		_, err = c.Validate(request, NewLoginEvent("test-account", Succeeded, LoginWithUser(user)))
+		assert.Nil(t, err)
+
+		request = request.WithContext(ContextWithMinimizationProfile(request.Context(), callProfile))
+		_, err = c.Validate(request, NewLoginEvent("test-account", Succeeded, LoginWithUser(user)))
+		assert.Nil(t, err)
+
+		assert.Len(t, bodies, 2)
+		AssertMinimization(t, clientProfile, bodies[0])
+		assert.ErrorIs(t, CheckMinimization(callProfile, bodies[0]), ErrMinimizationViolation)
+		AssertMinimization(t, callProfile, bodies[1])
+	})
+
+	t.Run("With an unknown field", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithMinimizationProfile(MinimizationProfile{DeniedFields: []string{"user.password"}}))
+		assert.Nil(t, c)
+		assert.ErrorIs(t, err, ErrWrongMinimizationProfileValue)
+	})
+
+	t.Run("With an unknown field in the call", func(t *testing.T) {
+		c, err := NewClient("your-api-key")
+		assert.Nil(t, err)
+
+		addr := "192.168.1.1"
+		method := http.MethodPost
+		ctx := ContextWithMinimizationProfile(context.Background(), MinimizationProfile{DeniedFields: []string{"user.password"}})
+		_, err = c.ValidateFromMetadata(ctx, NewLoginEvent("test-account", Succeeded), &RequestMetadata{Addr: &addr, Method: &method})
+		assert.ErrorIs(t, err, ErrWrongMinimizationProfileValue)
+	})
+}
+
+func ExampleCheckMinimization() {
+	profile := MinimizationProfile{Name: "eu", DeniedFields: []string{"header.from"}}
+	payload := &LoginRequestPayload{CommonRequestPayload: CommonRequestPayload{Header: Header{From: "john.doe@example.com"}}}
+
+	fmt.Println(CheckMinimization(profile, payload))
+	// Output: payload does not comply with the MinimizationProfile: profile "eu" forbids header.from
+}
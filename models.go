@@ -1,31 +1,91 @@
 package fraudsdkgo
 
 import (
+	"context"
 	"net/http"
+	"net/netip"
+	"time"
 )
 
+//go:generate go run ./cmd/schemagen -dir . -out schemas
+
 // Client is used to interact with the DataDome's Account Protect API.
 // This structure contains all the informations specified through the [ClientOption]'s functions.
 type Client struct {
-	Endpoint    string
-	FraudAPIKey string
-	Timeout     int
+	Endpoint            string
+	FraudAPIKey         string
+	AccessLists         *AccessLists
+	AccessListsFile     string
+	CDN                 *CDN
+	ClientIDSources     []ClientIDSource
+	FallbackIP          string
+	ForwardedHeader     bool
+	HeaderFingerprint   bool
+	MinimizationProfile *MinimizationProfile
+	MissingIPPolicy     MissingIPPolicy
+	Policy              *Policy
+	PseudonymizationKey []byte
+	StrictValidation    bool
+	Timeout             int
+	TruncationHook      TruncationHook
+	TruncationSizes     map[ApiFields]int
+	TrustedHops         int
+	TrustedProxies      []string
+
+	accessLists     *accessListsStore
+	httpClient      *http.Client
+	moduleName      string
+	moduleVersion   string
+	trustedNetworks []netip.Prefix
+}
 
-	httpClient    *http.Client
-	moduleName    string
-	moduleVersion string
+// TruncatedField describes a field of the [Header] that has been truncated before being sent
+// to the Account Protect API. The lengths are expressed in bytes.
+type TruncatedField struct {
+	Field          ApiFields
+	OriginalLength int
+	Length         int
 }
 
+// TruncationHook describes the signature of the function called when fields of the [Header] are truncated.
+type TruncationHook func(ctx context.Context, fields []TruncatedField)
+
+// MissingIPPolicy describes the possible behaviors of the [Client] when the IP of the emitter
+// cannot be found in the incoming request (e.g. when the RemoteAddr is a Unix socket).
+type MissingIPPolicy string
+
+const (
+	// MissingIPFail fails the call with an error wrapping [ErrIPNotFound].
+	MissingIPFail MissingIPPolicy = "fail"
+	// MissingIPFallback uses the FallbackIP of the [Client].
+	MissingIPFallback MissingIPPolicy = "fallback"
+	// MissingIPOmit sends the payload with an empty IP.
+	MissingIPOmit MissingIPPolicy = "omit"
+)
+
 // Event describes the methods that need to be implemented to create a new event type.
+// When called through [Client.ValidateFromMetadata] or [Client.CollectFromMetadata], the request only
+// carries the context and the method of the call: the information about the emitter must be read from the [Header].
 type Event interface {
 	Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error)
 	Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error)
 }
 
+// Describer is implemented by the events able to report their account and their type.
+// All the events of this package implement it. The [Policy] conditions on the accounts and the event types,
+// and the account lists of the [AccessLists], are not met by the events not implementing it: the other
+// conditions and lists still apply.
+type Describer interface {
+	// Describe returns the account and the type of the event.
+	Describe() (account string, event Action)
+}
+
 // AllowedRequestPayload describes the allowed request payloads to perform a request
 // to the Account Protect API.
 type AllowedRequestPayload interface {
-	LoginRequestPayload | RegistrationRequestPayload | AccountUpdateRequestPayload | PasswordUpdateRequestPayload
+	LoginRequestPayload | RegistrationRequestPayload | AccountUpdateRequestPayload | PasswordUpdateRequestPayload |
+		ContactUpdateRequestPayload | PaymentMethodRequestPayload | SessionRequestPayload |
+		PromoCodeRequestPayload | ReferralRequestPayload | LoyaltyRequestPayload | ContentRequestPayload
 }
 
 // Operation describes the available operations related to fraud protection that can be performed.
@@ -40,10 +100,17 @@ const (
 type Action string
 
 const (
-	AccountUpdate  Action = "account-update"
-	Login          Action = "login"
-	Registration   Action = "registration"
-	PasswordUpdate Action = "password-update"
+	AccountUpdate       Action = "account-update"
+	ContactUpdate       Action = "contact-update"
+	ContentPosting      Action = "content-posting"
+	Login               Action = "login"
+	Loyalty             Action = "loyalty"
+	Registration        Action = "registration"
+	PasswordUpdate      Action = "password-update"
+	PaymentMethod       Action = "payment-method"
+	PromoCodeRedemption Action = "promo-code-redemption"
+	Referral            Action = "referral"
+	SessionToken        Action = "session-token"
 )
 
 // ResponseStatus describes the possible status outcome.
@@ -53,6 +120,10 @@ const (
 	OK      ResponseStatus = "ok"
 	Failure ResponseStatus = "failure"
 	Timeout ResponseStatus = "timeout"
+	// Allowlisted is the status of the responses of the events matching the allow list (see [AccessLists]).
+	Allowlisted ResponseStatus = "allowlisted"
+	// Denylisted is the status of the responses of the events matching the deny list (see [AccessLists]).
+	Denylisted ResponseStatus = "denylisted"
 )
 
 // LoginStatus describes the possible status of an action.
@@ -119,33 +190,53 @@ const (
 
 // Header is used to store the information from the incoming request.
 type Header struct {
-	Accept                 string  `json:"accept"`
-	AcceptCharset          string  `json:"acceptCharset"`
-	AcceptEncoding         string  `json:"acceptEncoding"`
-	AcceptLanguage         string  `json:"acceptLanguage"`
-	Addr                   string  `json:"addr"`
-	ClientID               string  `json:"clientID"`
-	Connection             string  `json:"connection"`
-	ContentType            string  `json:"contentType"`
-	From                   string  `json:"from"`
-	Host                   string  `json:"host"`
-	Method                 string  `json:"method"`
-	Referer                string  `json:"referer"`
-	Request                string  `json:"request"`
-	Origin                 string  `json:"origin"`
-	Port                   int     `json:"port"`
-	Protocol               string  `json:"protocol"`
-	SecCHUA                *string `json:"secCHUA,omitempty"`
-	SecCHUAMobile          *string `json:"secCHUAMobile,omitempty"`
-	SecCHUAPlatform        *string `json:"secCHUAPlatform,omitempty"`
-	SecCHUAArch            *string `json:"secCHUAArch,omitempty"`
-	SecCHUAFullVersionList *string `json:"secCHUAFullVersionList,omitempty"`
-	SecCHUAModel           *string `json:"secCHUAModel,omitempty"`
-	SecCHDeviceMemory      *string `json:"secCHDeviceMemory,omitempty"`
-	ServerHostname         string  `json:"serverHostname"`
-	UserAgent              string  `json:"userAgent"`
-	XForwardedForIP        string  `json:"xForwardedForIp"`
-	XRealIP                string  `json:"xRealIp"`
+	Accept                 string    `json:"accept"`
+	AcceptCharset          string    `json:"acceptCharset"`
+	AcceptEncoding         string    `json:"acceptEncoding"`
+	AcceptLanguage         string    `json:"acceptLanguage"`
+	Addr                   string    `json:"addr"`
+	CDNLocation            *Location `json:"cdnLocation,omitempty"`
+	ClientID               string    `json:"clientID"`
+	Connection             string    `json:"connection"`
+	ContentType            string    `json:"contentType"`
+	From                   string    `json:"from"`
+	HeaderOrder            *string   `json:"headerOrder,omitempty"`
+	HeaderSetHash          *string   `json:"headerSetHash,omitempty"`
+	Host                   string    `json:"host"`
+	JA3                    *string   `json:"ja3,omitempty"`
+	JA4                    *string   `json:"ja4,omitempty"`
+	Method                 string    `json:"method"`
+	Referer                string    `json:"referer"`
+	Request                string    `json:"request"`
+	Origin                 string    `json:"origin"`
+	Port                   int       `json:"port"`
+	Protocol               string    `json:"protocol"`
+	SecCHUA                *string   `json:"secCHUA,omitempty"`
+	SecCHUAMobile          *string   `json:"secCHUAMobile,omitempty"`
+	SecCHUAPlatform        *string   `json:"secCHUAPlatform,omitempty"`
+	SecCHUAArch            *string   `json:"secCHUAArch,omitempty"`
+	SecCHUAFullVersionList *string   `json:"secCHUAFullVersionList,omitempty"`
+	SecCHUAModel           *string   `json:"secCHUAModel,omitempty"`
+	SecCHDeviceMemory      *string   `json:"secCHDeviceMemory,omitempty"`
+	SecCHUABitness         *string   `json:"secCHUABitness,omitempty"`
+	SecCHUAFormFactors     *string   `json:"secCHUAFormFactors,omitempty"`
+	SecCHUAPlatformVersion *string   `json:"secCHUAPlatformVersion,omitempty"`
+	SecCHUAWoW64           *string   `json:"secCHUAWoW64,omitempty"`
+	SecFetchDest           *string   `json:"secFetchDest,omitempty"`
+	SecFetchMode           *string   `json:"secFetchMode,omitempty"`
+	SecFetchSite           *string   `json:"secFetchSite,omitempty"`
+	SecFetchUser           *string   `json:"secFetchUser,omitempty"`
+	SaveData               *string   `json:"saveData,omitempty"`
+	DNT                    *string   `json:"dnt,omitempty"`
+	Priority               *string   `json:"priority,omitempty"`
+	ServerHostname         string    `json:"serverHostname"`
+	UserAgent              string    `json:"userAgent"`
+	XForwardedForIP        string    `json:"xForwardedForIp"`
+	XRealIP                string    `json:"xRealIp"`
+
+	// resolvedAddr is the IP of the emitter when it cannot be forged by the emitter: the RemoteAddr,
+	// an address resolved through the trusted proxies, or the Addr of the [RequestMetadata].
+	resolvedAddr string
 }
 
 // RequestMetadata is used to specify the fields of the [Header] structure that need to be override.
@@ -159,7 +250,11 @@ type RequestMetadata struct {
 	Connection             *string
 	ContentType            *string
 	From                   *string
+	HeaderOrder            *string
+	HeaderSetHash          *string
 	Host                   *string
+	JA3                    *string
+	JA4                    *string
 	Method                 *string
 	Referer                *string
 	Request                *string
@@ -173,6 +268,17 @@ type RequestMetadata struct {
 	SecCHUAFullVersionList *string
 	SecCHUAModel           *string
 	SecCHDeviceMemory      *string
+	SecCHUABitness         *string
+	SecCHUAFormFactors     *string
+	SecCHUAPlatformVersion *string
+	SecCHUAWoW64           *string
+	SecFetchDest           *string
+	SecFetchMode           *string
+	SecFetchSite           *string
+	SecFetchUser           *string
+	SaveData               *string
+	DNT                    *string
+	Priority               *string
 	ServerHostname         *string
 	UserAgent              *string
 	XForwardedForIP        *string
@@ -187,9 +293,13 @@ type Module struct {
 }
 
 // Session is used to store the information about the user's session.
+// The timestamps may be set from a [time.Time] with [Session.SetCreatedAt] and [Session.SetExpiresAt].
 type Session struct {
-	ID        *string `json:"id,omitempty"`
-	CreatedAt *string `json:"createdAt,omitempty"`
+	ID                  *string `json:"id,omitempty"`
+	ClientApplicationID *string `json:"clientApplicationId,omitempty"`
+	CreatedAt           *string `json:"createdAt,omitempty"`
+	ExpiresAt           *string `json:"expiresAt,omitempty"`
+	TokenFamilyID       *string `json:"tokenFamilyId,omitempty"`
 }
 
 // Address is used to store the address information of a user.
@@ -208,6 +318,15 @@ type Location struct {
 	City        *string `json:"city,omitempty"`
 	Country     *string `json:"country,omitempty"`
 	CountryCode *string `json:"countryCode,omitempty"`
+	RegionCode  *string `json:"regionCode,omitempty"`
+}
+
+// MonetaryValue is used to describe an amount of money.
+// The Amount is expressed in the minor unit of the currency, e.g. `1050` for 10.50 EUR or `1050` for 1050 JPY,
+// and the Currency is expected to be an ISO 4217 currency code.
+type MonetaryValue struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
 }
 
 // Authentication is used to describe the user's authentication informations.
@@ -218,6 +337,8 @@ type Authentication struct {
 }
 
 // User is used to store the information of a user.
+// It may be instantiated with [NewUser] to normalize the email address and the phone number.
+// The CreatedAt timestamp may be set from a [time.Time] with [User.SetCreatedAt].
 type User struct {
 	ID                   string    `json:"id"`
 	Address              *Address  `json:"address,omitempty"`
@@ -228,6 +349,8 @@ type User struct {
 	ExternalURLs         *[]string `json:"externalUrls,omitempty"`
 	FirstName            *string   `json:"firstName,omitempty"`
 	LastName             *string   `json:"lastName,omitempty"`
+	NormalizedEmail      *string   `json:"normalizedEmail,omitempty"`
+	NormalizedPhone      *string   `json:"normalizedPhone,omitempty"`
 	PaymentMethodUpdated *bool     `json:"paymentMethodUpdated,omitempty"`
 	Phone                *string   `json:"phone,omitempty"`
 	PictureURLs          *[]string `json:"pictureUrls,omitempty"`
@@ -253,6 +376,71 @@ const (
 	PasswordUpdateLinkExpired PasswordUpdateStatus = "linkExpired"
 )
 
+// ContactType describes the possible contact points that can be updated.
+type ContactType string
+
+const (
+	EmailContactType ContactType = "email"
+	PhoneContactType ContactType = "phone"
+)
+
+// ContactUpdateStatus describes the possible status of the verification of a contact point update.
+type ContactUpdateStatus string
+
+const (
+	ContactUpdateRequested ContactUpdateStatus = "requested"
+	ContactUpdateVerified  ContactUpdateStatus = "verified"
+	ContactUpdateExpired   ContactUpdateStatus = "expired"
+)
+
+// PaymentMethodOperation describes the possible operations performed on a payment method.
+type PaymentMethodOperation string
+
+const (
+	PaymentMethodAdd    PaymentMethodOperation = "add"
+	PaymentMethodRemove PaymentMethodOperation = "remove"
+	PaymentMethodUpdate PaymentMethodOperation = "update"
+)
+
+// PaymentInstrumentType describes the possible types of payment instrument.
+type PaymentInstrumentType string
+
+const (
+	OtherPaymentInstrumentType PaymentInstrumentType = "other"
+	BankAccount                PaymentInstrumentType = "bankAccount"
+	Card                       PaymentInstrumentType = "card"
+	Wallet                     PaymentInstrumentType = "wallet"
+)
+
+// SessionTokenStatus describes the possible steps of the lifecycle of a session token.
+type SessionTokenStatus string
+
+const (
+	SessionTokenIssued            SessionTokenStatus = "issued"
+	SessionTokenRefreshed         SessionTokenStatus = "refreshed"
+	SessionTokenRevoked           SessionTokenStatus = "revoked"
+	SessionTokenReusedAfterRevoke SessionTokenStatus = "reusedAfterRevoke"
+)
+
+// LoyaltyOperation describes the possible operations performed on loyalty points.
+type LoyaltyOperation string
+
+const (
+	LoyaltyRedemption LoyaltyOperation = "redemption"
+	LoyaltyTransfer   LoyaltyOperation = "transfer"
+)
+
+// UserContentType describes the possible types of content posted by a user.
+type UserContentType string
+
+const (
+	OtherUserContentType UserContentType = "other"
+	CommentContent       UserContentType = "comment"
+	MessageContent       UserContentType = "message"
+	PostContent          UserContentType = "post"
+	ReviewContent        UserContentType = "review"
+)
+
 // CommonRequestPayload describes the common fields for the event's request payloads.
 type CommonRequestPayload struct {
 	Account string `json:"account"`
@@ -336,15 +524,189 @@ type PasswordUpdateRequestPayload struct {
 	User    User                 `json:"user"`
 }
 
+// ContactUpdateEvent is used to store the fields for a [ContactUpdate] event.
+type ContactUpdateEvent struct {
+	Account       string
+	Action        Action
+	ContactType   ContactType
+	ElapsedTime   *time.Duration
+	Hashed        bool
+	NewValue      string
+	PreviousValue string
+	Session       *Session
+	Status        ContactUpdateStatus
+	User          *User
+}
+
+// ContactUpdateRequestPayload describes the expected fields of the payload to be sent to the
+// Account Protect API for a [ContactUpdateEvent].
+type ContactUpdateRequestPayload struct {
+	CommonRequestPayload
+	ContactType   ContactType         `json:"contactType"`
+	ElapsedTimeMs *int64              `json:"elapsedTimeMs,omitempty"`
+	Hashed        bool                `json:"hashed"`
+	NewValue      string              `json:"newValue"`
+	PreviousValue string              `json:"previousValue"`
+	Session       *Session            `json:"session,omitempty"`
+	Status        ContactUpdateStatus `json:"status"`
+	User          *User               `json:"user,omitempty"`
+}
+
+// PaymentMethodEvent is used to store the fields for a [PaymentMethod] event.
+type PaymentMethodEvent struct {
+	Account              string
+	Action               Action
+	BIN                  *string
+	InstrumentType       PaymentInstrumentType
+	IssuerCountryCode    *string
+	Last4                *string
+	Operation            PaymentMethodOperation
+	Session              *Session
+	ThreeDSecure         *bool
+	TokenizationProvider *string
+	User                 *User
+}
+
+// PaymentMethodRequestPayload describes the expected fields of the payload to be sent to the
+// Account Protect API for a [PaymentMethodEvent].
+type PaymentMethodRequestPayload struct {
+	CommonRequestPayload
+	BIN                  *string                `json:"bin,omitempty"`
+	InstrumentType       PaymentInstrumentType  `json:"instrumentType"`
+	IssuerCountryCode    *string                `json:"issuerCountryCode,omitempty"`
+	Last4                *string                `json:"last4,omitempty"`
+	Operation            PaymentMethodOperation `json:"operation"`
+	Session              *Session               `json:"session,omitempty"`
+	ThreeDSecure         *bool                  `json:"threeDSecure,omitempty"`
+	TokenizationProvider *string                `json:"tokenizationProvider,omitempty"`
+	User                 *User                  `json:"user,omitempty"`
+}
+
+// SessionEvent is used to store the fields for a [SessionToken] event.
+type SessionEvent struct {
+	Account        string
+	Action         Action
+	Authentication *Authentication
+	Session        Session
+	Status         SessionTokenStatus
+	User           *User
+}
+
+// SessionRequestPayload describes the expected fields of the payload to be sent to the
+// Account Protect API for a [SessionEvent].
+type SessionRequestPayload struct {
+	CommonRequestPayload
+	Authentication *Authentication    `json:"authentication,omitempty"`
+	Session        Session            `json:"session"`
+	Status         SessionTokenStatus `json:"status"`
+	User           *User              `json:"user,omitempty"`
+}
+
+// PromoCodeEvent is used to store the fields for a [PromoCodeRedemption] event.
+type PromoCodeEvent struct {
+	Account string
+	Action  Action
+	Code    string
+	Session *Session
+	User    *User
+	Value   *MonetaryValue
+}
+
+// PromoCodeRequestPayload describes the expected fields of the payload to be sent to the
+// Account Protect API for a [PromoCodeEvent].
+type PromoCodeRequestPayload struct {
+	CommonRequestPayload
+	Code    string         `json:"code"`
+	Session *Session       `json:"session,omitempty"`
+	User    *User          `json:"user,omitempty"`
+	Value   *MonetaryValue `json:"value,omitempty"`
+}
+
+// ReferralEvent is used to store the fields for a [Referral] event.
+type ReferralEvent struct {
+	Account         string
+	Action          Action
+	Code            string
+	ReferrerAccount string
+	Session         *Session
+	User            *User
+	Value           *MonetaryValue
+}
+
+// ReferralRequestPayload describes the expected fields of the payload to be sent to the
+// Account Protect API for a [ReferralEvent].
+type ReferralRequestPayload struct {
+	CommonRequestPayload
+	Code            string         `json:"code"`
+	ReferrerAccount string         `json:"referrerAccount"`
+	Session         *Session       `json:"session,omitempty"`
+	User            *User          `json:"user,omitempty"`
+	Value           *MonetaryValue `json:"value,omitempty"`
+}
+
+// LoyaltyEvent is used to store the fields for a [Loyalty] event.
+type LoyaltyEvent struct {
+	Account          string
+	Action           Action
+	Operation        LoyaltyOperation
+	Points           int
+	ProgramID        *string
+	RecipientAccount *string
+	Session          *Session
+	User             *User
+	Value            *MonetaryValue
+}
+
+// LoyaltyRequestPayload describes the expected fields of the payload to be sent to the
+// Account Protect API for a [LoyaltyEvent].
+type LoyaltyRequestPayload struct {
+	CommonRequestPayload
+	Operation        LoyaltyOperation `json:"operation"`
+	Points           int              `json:"points"`
+	ProgramID        *string          `json:"programId,omitempty"`
+	RecipientAccount *string          `json:"recipientAccount,omitempty"`
+	Session          *Session         `json:"session,omitempty"`
+	User             *User            `json:"user,omitempty"`
+	Value            *MonetaryValue   `json:"value,omitempty"`
+}
+
+// ContentEvent is used to store the fields for a [ContentPosting] event.
+type ContentEvent struct {
+	Account     string
+	Action      Action
+	ContentID   *string
+	ContentType UserContentType
+	Hash        string
+	Language    *string
+	Length      int
+	LinkCount   int
+	Session     *Session
+	User        *User
+}
+
+// ContentRequestPayload describes the expected fields of the payload to be sent to the
+// Account Protect API for a [ContentEvent].
+type ContentRequestPayload struct {
+	CommonRequestPayload
+	ContentID   *string         `json:"contentId,omitempty"`
+	ContentType UserContentType `json:"contentType"`
+	Hash        string          `json:"hash"`
+	Language    *string         `json:"language,omitempty"`
+	Length      int             `json:"length"`
+	LinkCount   int             `json:"linkCount"`
+	Session     *Session        `json:"session,omitempty"`
+	User        *User           `json:"user,omitempty"`
+}
+
 // SuccessResponsePayload is used for success response returned by the Account Protect API.
 type SuccessResponsePayload struct {
 	Action   ResponseAction `json:"action"`
-	Status   ResponseStatus
-	Reasons  []string  `json:"reasons,omitempty"`
-	EventID  *string   `json:"eventId,omitempty"`
-	IP       *string   `json:"ip,omitempty"`
-	Location *Location `json:"location,omitempty"`
-	Score    *int      `json:"score,omitempty"`
+	Status   ResponseStatus `schemagen:"-"`
+	Reasons  []string       `json:"reasons,omitempty"`
+	EventID  *string        `json:"eventId,omitempty"`
+	IP       *string        `json:"ip,omitempty"`
+	Location *Location      `json:"location,omitempty"`
+	Score    *int           `json:"score,omitempty"`
 }
 
 // ErrorInfo is used to provide more precision about the error returned by the Account Protect API.
@@ -363,4 +725,6 @@ type ErrorResponsePayload struct {
 type ResponsePayload struct {
 	SuccessResponsePayload
 	ErrorResponsePayload
+	// Policy describes the rule of the [Policy] of the [Client] that replaced the recommended action, if any.
+	Policy *PolicyDecision `json:"-"`
 }
@@ -0,0 +1,302 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// emailProvider describes how a mailbox provider interprets the local part of the email addresses.
+type emailProvider struct {
+	domain       string
+	ignoreDots   bool
+	tagSeparator string
+}
+
+// emailProviders lists the providers with a specific interpretation of the local part, by domain.
+var emailProviders = map[string]emailProvider{
+	"gmail.com":      {domain: "gmail.com", ignoreDots: true, tagSeparator: "+"},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true, tagSeparator: "+"},
+	"fastmail.com":   {domain: "fastmail.com", tagSeparator: "+"},
+	"hotmail.com":    {domain: "hotmail.com", tagSeparator: "+"},
+	"icloud.com":     {domain: "icloud.com", tagSeparator: "+"},
+	"live.com":       {domain: "live.com", tagSeparator: "+"},
+	"mac.com":        {domain: "icloud.com", tagSeparator: "+"},
+	"me.com":         {domain: "icloud.com", tagSeparator: "+"},
+	"msn.com":        {domain: "msn.com", tagSeparator: "+"},
+	"outlook.com":    {domain: "outlook.com", tagSeparator: "+"},
+	"pm.me":          {domain: "proton.me", tagSeparator: "+"},
+	"proton.me":      {domain: "proton.me", tagSeparator: "+"},
+	"protonmail.ch":  {domain: "proton.me", tagSeparator: "+"},
+	"protonmail.com": {domain: "proton.me", tagSeparator: "+"},
+	"rocketmail.com": {domain: "rocketmail.com", tagSeparator: "-"},
+	"ymail.com":      {domain: "ymail.com", tagSeparator: "-"},
+}
+
+// getEmailProvider returns the provider of the domain, if known.
+// The regional domains of Yahoo (e.g. `yahoo.fr` or `yahoo.co.uk`) are also supported.
+func getEmailProvider(domain string) (emailProvider, bool) {
+	if provider, ok := emailProviders[domain]; ok {
+		return provider, true
+	}
+	if strings.HasPrefix(domain, "yahoo.") {
+		return emailProvider{domain: domain, tagSeparator: "-"}, true
+	}
+	return emailProvider{}, false
+}
+
+// NormalizeEmail returns the canonical form of the email address, so that the different spellings
+// of the same mailbox are identical:
+//   - the address is trimmed and lowercased
+//   - the domain is converted to its ASCII form (e.g. `bücher.example` becomes `xn--bcher-kva.example`),
+//     without the IDNA mapping nor the Unicode normalization (NFC): a domain spelled with combining
+//     characters (e.g. `u` followed by U+0308) or full-width characters is not converted to the same form
+//     as its precomposed spelling
+//   - for the known providers, the sub-addressing tag (e.g. `+promo`) is removed, the dots are removed
+//     when ignored by the provider (e.g. Gmail) and the alias domains are replaced (e.g. `googlemail.com`)
+//
+// An error wrapping [ErrInvalidEmail] is returned if the value is not an email address.
+func NormalizeEmail(email string) (string, error) {
+	email = normalizeEmailValue(email)
+	index := strings.LastIndex(email, "@")
+	if index <= 0 || index == len(email)-1 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+	local, domain := email[:index], strings.TrimSuffix(email[index+1:], ".")
+
+	domain, err := toASCIIDomain(domain)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+
+	if provider, ok := getEmailProvider(domain); ok {
+		if tag := strings.Index(local, provider.tagSeparator); tag > 0 {
+			local = local[:tag]
+		}
+		if provider.ignoreDots {
+			local = strings.ReplaceAll(local, ".", "")
+		}
+		domain = provider.domain
+	}
+	if local == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+	return local + "@" + domain, nil
+}
+
+// NormalizePhone returns the E.164 form of the phone number (e.g. `+33612345678`).
+// The number may be formatted with spaces, dots, dashes or parentheses. It is considered as international
+// if it starts with `+` or with the `00` international prefix (`011` in the North American Numbering Plan).
+// Otherwise, it is considered as a national number of the region, an ISO 3166-1 alpha-2 code (e.g. `FR`),
+// and its trunk prefix (e.g. the leading `0`) is removed.
+//
+// An error wrapping [ErrInvalidPhone] is returned if the value is not a phone number or the region is unknown.
+func NormalizePhone(phone string, region string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(phone))
+	if index := strings.IndexAny(value, "x#;e"); index >= 0 {
+		// remove the extension (e.g. `ext. 123`, `x123` or `;ext=123`)
+		value = value[:index]
+	}
+
+	international := strings.HasPrefix(value, "+")
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && digits.Len() == 0, r == ' ', r == '.', r == '-', r == '/', r == '(', r == ')':
+		default:
+			return "", fmt.Errorf("%w: %q", ErrInvalidPhone, phone)
+		}
+	}
+	number := digits.String()
+
+	region = strings.ToUpper(strings.TrimSpace(region))
+	callingCode, knownRegion := callingCodes[region]
+	if !international {
+		switch {
+		case callingCode == "1" && strings.HasPrefix(number, "011"):
+			number, international = number[3:], true
+		case callingCode != "1" && strings.HasPrefix(number, "00"):
+			number, international = number[2:], true
+		}
+	}
+	if !international {
+		if !knownRegion {
+			return "", fmt.Errorf("%w: unknown region %q", ErrInvalidPhone, region)
+		}
+		trunkPrefix, ok := trunkPrefixes[region]
+		if !ok {
+			trunkPrefix = "0"
+		}
+		if callingCode == "1" {
+			trunkPrefix = "1"
+		}
+		if trunkPrefix != "" && strings.HasPrefix(number, trunkPrefix) {
+			number = number[len(trunkPrefix):]
+		}
+		number = callingCode + number
+	}
+
+	// E.164 numbers contain at most 15 digits, the country calling code included
+	if len(number) < 7 || len(number) > 15 || number[0] == '0' {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhone, phone)
+	}
+	return "+" + number, nil
+}
+
+// normalizeEmailValue trims and lowercases the email address.
+func normalizeEmailValue(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizePhoneValue keeps the digits of the phone number, preceded by `+` if the number starts with it.
+func normalizePhoneValue(phone string) string {
+	phone = strings.TrimSpace(phone)
+	var b strings.Builder
+	if strings.HasPrefix(phone, "+") {
+		b.WriteByte('+')
+	}
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// normalizeTextValue lowercases the value and collapses its whitespaces.
+func normalizeTextValue(value string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(value), unicode.IsSpace), " ")
+}
+
+// toASCIIDomain converts the labels of the domain containing non-ASCII characters to their
+// Punycode form, prefixed by `xn--` (see RFC 5891).
+// The labels are encoded as is: the IDNA mapping (UTS #46) and the NFC normalization are not applied.
+func toASCIIDomain(domain string) (string, error) {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if label == "" {
+			return "", fmt.Errorf("empty label in domain %q", domain)
+		}
+		if isASCII(label) {
+			continue
+		}
+		if !utf8.ValidString(label) {
+			return "", fmt.Errorf("invalid label %q", label)
+		}
+		encoded, err := encodePunycode(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = "xn--" + encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// isASCII returns true if the value only contains ASCII characters.
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Punycode parameters (see RFC 3492).
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+// encodePunycode encodes the label with the Punycode algorithm described in RFC 3492.
+func encodePunycode(label string) (string, error) {
+	runes := []rune(label)
+	output := make([]byte, 0, len(label)*2)
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			output = append(output, byte(r))
+		}
+	}
+	basicLength := len(output)
+	handled := basicLength
+	if basicLength > 0 {
+		output = append(output, '-')
+	}
+
+	n, delta, bias := rune(punycodeInitialN), 0, punycodeInitialBias
+	for handled < len(runes) {
+		next := rune(utf8.MaxRune)
+		for _, r := range runes {
+			if r >= n && r < next {
+				next = r
+			}
+		}
+		if int(next-n) > (1<<31-1-delta)/(handled+1) {
+			return "", fmt.Errorf("punycode overflow for label %q", label)
+		}
+		delta += int(next-n) * (handled + 1)
+		n = next
+
+		for _, r := range runes {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+			q := delta
+			for k := punycodeBase; ; k += punycodeBase {
+				t := k - bias
+				if t < punycodeTMin {
+					t = punycodeTMin
+				} else if t > punycodeTMax {
+					t = punycodeTMax
+				}
+				if q < t {
+					break
+				}
+				output = append(output, punycodeDigit(t+(q-t)%(punycodeBase-t)))
+				q = (q - t) / (punycodeBase - t)
+			}
+			output = append(output, punycodeDigit(q))
+			bias = adaptPunycodeBias(delta, handled+1, handled == basicLength)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return string(output), nil
+}
+
+// punycodeDigit returns the character encoding the digit: `a` to `z` for 0 to 25, `0` to `9` for 26 to 35.
+func punycodeDigit(digit int) byte {
+	if digit < 26 {
+		return byte('a' + digit)
+	}
+	return byte('0' + digit - 26)
+}
+
+// adaptPunycodeBias is the bias adaptation function of RFC 3492.
+func adaptPunycodeBias(delta, numPoints int, firstTime bool) int {
+	if firstTime {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
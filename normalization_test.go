@@ -0,0 +1,190 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{"Lowercased and trimmed", " John.Doe@Example.COM ", "john.doe@example.com"},
+		{"Gmail dots and tag", "John.Doe+promo@gmail.com", "johndoe@gmail.com"},
+		{"Googlemail alias", "johndoe@googlemail.com", "johndoe@gmail.com"},
+		{"Outlook tag", "john.doe+news@outlook.com", "john.doe@outlook.com"},
+		{"iCloud alias", "john+shop@me.com", "john@icloud.com"},
+		{"Yahoo regional domain", "john-shop@yahoo.fr", "john@yahoo.fr"},
+		{"Unknown provider keeps the tag", "john+shop@example.com", "john+shop@example.com"},
+		{"IDN domain", "john@Bücher.example", "john@xn--bcher-kva.example"},
+		{"Trailing dot in the domain", "john@example.com.", "john@example.com"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NormalizeEmail(tc.email)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("IDN domain without IDNA mapping", func(t *testing.T) {
+		// the precomposed and the decomposed spellings of `bücher` are not normalized to the same form
+		decomposed, err := NormalizeEmail("john@bu\u0308cher.example")
+		assert.Nil(t, err)
+		assert.NotEqual(t, "john@xn--bcher-kva.example", decomposed)
+
+		fullWidth, err := NormalizeEmail("john@\uff45xample.com")
+		assert.Nil(t, err)
+		assert.NotEqual(t, "john@example.com", fullWidth)
+	})
+
+	for _, email := range []string{"", "john", "@example.com", "john@", "john@example..com"} {
+		_, err := NormalizeEmail(email)
+		assert.ErrorIs(t, err, ErrInvalidEmail, email)
+	}
+}
+
+func TestEncodePunycode(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"bücher", "bcher-kva"},
+		{"münchen", "mnchen-3ya"},
+		{"ü", "tda"},
+		{"日本語", "wgv71a119e"},
+	}
+
+	for _, tc := range tests {
+		got, err := encodePunycode(tc.label)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		phone  string
+		region string
+		want   string
+	}{
+		{"International format", "+33 6 12 34 56 78", "", "+33612345678"},
+		{"International prefix", "0033 6 12 34 56 78", "FR", "+33612345678"},
+		{"National format", "06.12.34.56.78", "fr", "+33612345678"},
+		{"North American format", "(415) 555-2671", "US", "+14155552671"},
+		{"North American trunk prefix", "1-415-555-2671", "US", "+14155552671"},
+		{"North American international prefix", "011 44 20 7946 0958", "US", "+442079460958"},
+		{"Italian leading zero", "06 1234 5678", "IT", "+390612345678"},
+		{"Russian trunk prefix", "8 912 345-67-89", "RU", "+79123456789"},
+		{"Hungarian trunk prefix", "06 20 123 4567", "HU", "+36201234567"},
+		{"With an extension", "+44 20 7946 0958 ext. 12", "", "+442079460958"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NormalizePhone(tc.phone, tc.region)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	invalid := []struct {
+		phone  string
+		region string
+	}{
+		{"", "FR"},
+		{"not a phone", "FR"},
+		{"06 12 34 56 78", ""},
+		{"06 12 34 56 78", "ZZ"},
+		{"+33 6 12 34 56 78 90 12 34", ""},
+		{"+0 12345678", ""},
+	}
+	for _, tc := range invalid {
+		_, err := NormalizePhone(tc.phone, tc.region)
+		assert.ErrorIs(t, err, ErrInvalidPhone, tc.phone)
+	}
+}
+
+func TestNewUser(t *testing.T) {
+	t.Run("Without normalization", func(t *testing.T) {
+		user := NewUser("123456", UserWithEmail("John.Doe+promo@gmail.com"), UserWithPhone("06 12 34 56 78"))
+		assert.Equal(t, "123456", user.ID)
+		assert.Equal(t, "John.Doe+promo@gmail.com", *user.Email)
+		assert.Equal(t, "06 12 34 56 78", *user.Phone)
+		assert.Nil(t, user.NormalizedEmail)
+		assert.Nil(t, user.NormalizedPhone)
+	})
+
+	t.Run("Normalized alongside the raw values", func(t *testing.T) {
+		user := NewUser("123456",
+			UserWithEmail("John.Doe+promo@gmail.com"),
+			UserWithPhone("06 12 34 56 78"),
+			UserWithNormalization(NormalizeAlongside, "FR"),
+		)
+		assert.Equal(t, "John.Doe+promo@gmail.com", *user.Email)
+		assert.Equal(t, "johndoe@gmail.com", *user.NormalizedEmail)
+		assert.Equal(t, "06 12 34 56 78", *user.Phone)
+		assert.Equal(t, "+33612345678", *user.NormalizedPhone)
+	})
+
+	t.Run("Normalized instead of the raw values", func(t *testing.T) {
+		user := NewUser("123456",
+			UserWithNormalization(NormalizeInstead, "FR"),
+			UserWithEmail("John.Doe+promo@gmail.com"),
+			UserWithPhone("not a phone"),
+			UserWithFirstName("John"),
+			UserWithLastName("Doe"),
+			UserWithAddress(Address{}),
+		)
+		assert.Equal(t, "johndoe@gmail.com", *user.Email)
+		assert.Equal(t, "not a phone", *user.Phone)
+		assert.Nil(t, user.NormalizedEmail)
+		assert.Nil(t, user.NormalizedPhone)
+		assert.Equal(t, "John", *user.FirstName)
+		assert.Equal(t, "Doe", *user.LastName)
+		assert.NotNil(t, user.Address)
+	})
+
+	t.Run("Unknown mode", func(t *testing.T) {
+		user := NewUser("123456",
+			UserWithEmail("John.Doe+promo@gmail.com"),
+			UserWithNormalization("replace", "FR"),
+		)
+		assert.Equal(t, "John.Doe+promo@gmail.com", *user.Email)
+		assert.Nil(t, user.NormalizedEmail)
+
+		user = NewUser("123456",
+			UserWithEmail("John.Doe+promo@gmail.com"),
+			UserWithNormalization(NormalizeInstead, "FR"),
+			UserWithNormalization("", "FR"),
+		)
+		assert.Equal(t, "johndoe@gmail.com", *user.Email)
+	})
+}
+
+func ExampleNormalizeEmail() {
+	email, _ := NormalizeEmail("John.Doe+promo@gmail.com")
+
+	fmt.Println(email)
+	// Output: johndoe@gmail.com
+}
+
+func ExampleNormalizePhone() {
+	phone, _ := NormalizePhone("06 12 34 56 78", "FR")
+
+	fmt.Println(phone)
+	// Output: +33612345678
+}
+
+func ExampleNewUser() {
+	user := NewUser("123456", UserWithEmail("John.Doe+promo@gmail.com"), UserWithNormalization(NormalizeAlongside, "FR"))
+
+	fmt.Println(*user.Email, *user.NormalizedEmail)
+	// Output: John.Doe+promo@gmail.com johndoe@gmail.com
+}
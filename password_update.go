@@ -35,6 +35,22 @@ func NewPasswordUpdateEvent(account string, user User, reason PasswordUpdateReas
 	return event
 }
 
+// Check returns the problems found in the fields of the [PasswordUpdateEvent] (see [Checker]).
+func (e *PasswordUpdateEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	checkOneOf(&pc, "reason", e.Reason, ForcedReset, ForgotPassword, UserUpdate)
+	checkOneOf(&pc, "status", e.Status, PasswordUpdateAttempted, PasswordUpdateFailed, PasswordUpdateLinkExpired, PasswordUpdateSucceeded)
+	pc.user("user", &e.User)
+	pc.session("session", e.Session)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [PasswordUpdateEvent] (see [Describer]).
+func (e *PasswordUpdateEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
 // Validate is used to construct the [PasswordUpdateRequestPayload] based on the information stored
 // in the [PasswordUpdateEvent] structure and performs the validation request to the Account Protect API.
 // An error may be returned in case of error when performing the request.
@@ -0,0 +1,167 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PaymentMethodOption describes the functional option signature to customize the [PaymentMethodEvent] behavior.
+type PaymentMethodOption func(*PaymentMethodEvent)
+
+// PaymentMethodWithBIN is a functional option to set the Bank Identification Number of the card.
+func PaymentMethodWithBIN(bin string) PaymentMethodOption {
+	return func(e *PaymentMethodEvent) {
+		e.BIN = &bin
+	}
+}
+
+// PaymentMethodWithIssuerCountryCode is a functional option to set the ISO 3166-1 alpha-2 country code of the issuer.
+func PaymentMethodWithIssuerCountryCode(countryCode string) PaymentMethodOption {
+	return func(e *PaymentMethodEvent) {
+		e.IssuerCountryCode = &countryCode
+	}
+}
+
+// PaymentMethodWithLast4 is a functional option to set the last four digits of the payment instrument.
+func PaymentMethodWithLast4(last4 string) PaymentMethodOption {
+	return func(e *PaymentMethodEvent) {
+		e.Last4 = &last4
+	}
+}
+
+// PaymentMethodWithSession is a functional option to set the [Session] field.
+func PaymentMethodWithSession(session Session) PaymentMethodOption {
+	return func(e *PaymentMethodEvent) {
+		e.Session = &session
+	}
+}
+
+// PaymentMethodWithThreeDSecure is a functional option to specify whether a 3-D Secure authentication was performed.
+func PaymentMethodWithThreeDSecure(performed bool) PaymentMethodOption {
+	return func(e *PaymentMethodEvent) {
+		e.ThreeDSecure = &performed
+	}
+}
+
+// PaymentMethodWithTokenizationProvider is a functional option to set the provider used to tokenize the payment instrument.
+func PaymentMethodWithTokenizationProvider(provider string) PaymentMethodOption {
+	return func(e *PaymentMethodEvent) {
+		e.TokenizationProvider = &provider
+	}
+}
+
+// PaymentMethodWithUser is a functional option to set the [User] field.
+func PaymentMethodWithUser(user User) PaymentMethodOption {
+	return func(e *PaymentMethodEvent) {
+		e.User = &user
+	}
+}
+
+// NewPaymentMethodEvent instantiates a new [PaymentMethodEvent] that implements the [Event] interface.
+func NewPaymentMethodEvent(account string, operation PaymentMethodOperation, instrumentType PaymentInstrumentType, options ...PaymentMethodOption) *PaymentMethodEvent {
+	event := &PaymentMethodEvent{
+		Account:        account,
+		Action:         PaymentMethod,
+		InstrumentType: instrumentType,
+		Operation:      operation,
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(event)
+	}
+
+	return event
+}
+
+// Check returns the problems found in the fields of the [PaymentMethodEvent] (see [Checker]).
+func (e *PaymentMethodEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	checkOneOf(&pc, "operation", e.Operation, PaymentMethodAdd, PaymentMethodRemove, PaymentMethodUpdate)
+	checkOneOf(&pc, "instrumentType", e.InstrumentType, OtherPaymentInstrumentType, BankAccount, Card, Wallet)
+	pc.countryCode("issuerCountryCode", e.IssuerCountryCode)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [PaymentMethodEvent] (see [Describer]).
+func (e *PaymentMethodEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
+// buildRequestPayload is used to construct the [PaymentMethodRequestPayload] based on the information stored
+// in the [PaymentMethodEvent] structure.
+func (e *PaymentMethodEvent) buildRequestPayload(module *Module, header *Header) *PaymentMethodRequestPayload {
+	return &PaymentMethodRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: e.Account,
+			Header:  *header,
+			Module:  *module,
+		},
+		BIN:                  e.BIN,
+		InstrumentType:       e.InstrumentType,
+		IssuerCountryCode:    e.IssuerCountryCode,
+		Last4:                e.Last4,
+		Operation:            e.Operation,
+		Session:              e.Session,
+		ThreeDSecure:         e.ThreeDSecure,
+		TokenizationProvider: e.TokenizationProvider,
+		User:                 e.User,
+	}
+}
+
+// Validate is used to construct the [PaymentMethodRequestPayload] based on the information stored
+// in the [PaymentMethodEvent] structure and performs the validation request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *PaymentMethodEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/validate/payment/method", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		resp := &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+			},
+		}
+		if errors.Is(err, ErrRequestTimeout) {
+			resp.Status = Timeout
+		} else {
+			resp.Status = Failure
+		}
+		return resp, fmt.Errorf("fail to validate payment method request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		return handleErrorResponse(responsePayload), nil
+	}
+	resp, err := decodeResponse[ResponsePayload](responsePayload)
+	if err != nil {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Failure,
+			},
+		}, err
+	}
+	resp.Status = OK
+	return resp, nil
+}
+
+// Collect is used to construct the [PaymentMethodRequestPayload] based on the information stored
+// in the [PaymentMethodEvent] structure and performs the enrichment request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *PaymentMethodEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/collect/payment/method", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("fail to collect payment method request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		responsePayload := handleErrorResponse(responsePayload)
+		return &responsePayload.ErrorResponsePayload, nil
+	}
+	return nil, nil
+}
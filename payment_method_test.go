@@ -0,0 +1,75 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentMethodWithCardDetails(t *testing.T) {
+	event := NewPaymentMethodEvent("test-account", PaymentMethodAdd, Card,
+		PaymentMethodWithBIN("424242"),
+		PaymentMethodWithLast4("4242"),
+		PaymentMethodWithIssuerCountryCode("FR"),
+		PaymentMethodWithTokenizationProvider("stripe"),
+		PaymentMethodWithThreeDSecure(true),
+	)
+	assert.NotNil(t, event)
+	assert.Equal(t, "424242", *event.BIN)
+	assert.Equal(t, "4242", *event.Last4)
+	assert.Equal(t, "FR", *event.IssuerCountryCode)
+	assert.Equal(t, "stripe", *event.TokenizationProvider)
+	assert.True(t, *event.ThreeDSecure)
+
+	payload := event.buildRequestPayload(&Module{}, &Header{})
+	assert.Equal(t, event.BIN, payload.BIN)
+	assert.Equal(t, event.Last4, payload.Last4)
+	assert.Equal(t, event.IssuerCountryCode, payload.IssuerCountryCode)
+	assert.Equal(t, event.TokenizationProvider, payload.TokenizationProvider)
+	assert.Equal(t, event.ThreeDSecure, payload.ThreeDSecure)
+}
+
+func TestPaymentMethodWithSession(t *testing.T) {
+	sessionID := "123456"
+	session := Session{
+		ID: &sessionID,
+	}
+
+	event := NewPaymentMethodEvent("test-account", PaymentMethodRemove, Wallet, PaymentMethodWithSession(session))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Session)
+	assert.Equal(t, sessionID, *event.Session.ID)
+}
+
+func TestPaymentMethodWithUser(t *testing.T) {
+	event := NewPaymentMethodEvent("test-account", PaymentMethodUpdate, BankAccount, PaymentMethodWithUser(User{ID: "123456"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.User)
+	assert.Equal(t, "123456", event.User.ID)
+}
+
+func TestNewPaymentMethodEvent(t *testing.T) {
+	event := NewPaymentMethodEvent("test-account", PaymentMethodAdd, Card)
+	assert.NotNil(t, event)
+	assert.Equal(t, "test-account", event.Account)
+	assert.Equal(t, PaymentMethod, event.Action)
+	assert.Equal(t, PaymentMethodAdd, event.Operation)
+	assert.Equal(t, Card, event.InstrumentType)
+	assert.Nil(t, event.BIN)
+	assert.Nil(t, event.ThreeDSecure)
+}
+
+func ExamplePaymentMethodWithThreeDSecure() {
+	event := NewPaymentMethodEvent("test-account", PaymentMethodAdd, Card, PaymentMethodWithThreeDSecure(false))
+
+	fmt.Println(*event.ThreeDSecure)
+	// Output: false
+}
+
+func ExamplePaymentMethodWithLast4() {
+	event := NewPaymentMethodEvent("test-account", PaymentMethodAdd, Card, PaymentMethodWithLast4("4242"))
+
+	fmt.Println(*event.Last4)
+	// Output: 4242
+}
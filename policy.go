@@ -0,0 +1,241 @@
+package fraudsdkgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Policy describes the local rules adjusting the recommendation of the Account Protect API,
+// e.g. to escalate [Allow] to [Challenge] above a score or to downgrade [Deny] for test accounts.
+//
+// The rules are evaluated in order on the successful responses, and the first matching rule
+// replaces the recommended action. The fired rule is reported in the Policy field of the [ResponsePayload].
+type Policy struct {
+	Name  string       `json:"name,omitempty"`
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule describes a rule of a [Policy]: the Action replaces the recommended action when
+// all the conditions are met.
+type PolicyRule struct {
+	Name       string           `json:"name"`
+	Conditions PolicyConditions `json:"when"`
+	Action     ResponseAction   `json:"action"`
+}
+
+// PolicyConditions describes the conditions of a [PolicyRule]. The empty conditions are ignored,
+// and a condition listing several values is met when one of them matches.
+//
+//   - Actions: the actions recommended by the Account Protect API
+//   - MinScore, MaxScore: the inclusive bounds of the score, not met when the response has no score
+//   - Reasons: the reasons returned by the Account Protect API
+//   - CountryCodes: the country code of the location returned by the Account Protect API
+//   - Events: the type of the event
+//   - Accounts: the account of the event
+//   - Headers: regular expressions matched against the fields of the [Header], identified by their JSON name.
+//     The expressions are compiled by [LoadPolicy] and [ClientWithPolicy]: the header conditions of a [Policy]
+//     assigned directly to the Policy field of the [Client] are never met.
+type PolicyConditions struct {
+	Actions      []ResponseAction  `json:"actions,omitempty"`
+	MinScore     *int              `json:"minScore,omitempty"`
+	MaxScore     *int              `json:"maxScore,omitempty"`
+	Reasons      []string          `json:"reasons,omitempty"`
+	CountryCodes []string          `json:"countryCodes,omitempty"`
+	Events       []Action          `json:"events,omitempty"`
+	Accounts     []string          `json:"accounts,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+
+	headerPatterns map[string]*regexp.Regexp
+}
+
+// PolicyDecision describes the [PolicyRule] that replaced the action recommended by the Account Protect API.
+type PolicyDecision struct {
+	Policy         string
+	Rule           string
+	OriginalAction ResponseAction
+}
+
+// policyInput stores the values on which the conditions of a [PolicyRule] are evaluated.
+type policyInput struct {
+	response *ResponsePayload
+	event    Action
+	account  string
+	header   *Header
+}
+
+// LoadPolicy returns the [Policy] described by the JSON document, e.g.:
+//
+//	{
+//	  "name": "default",
+//	  "rules": [
+//	    {"name": "high-score", "when": {"actions": ["allow"], "minScore": 80}, "action": "challenge"},
+//	    {"name": "qa-accounts", "when": {"accounts": ["qa@example.com"]}, "action": "allow"}
+//	  ]
+//	}
+//
+// An error wrapping [ErrWrongPolicyValue] is returned if the document describes an invalid policy.
+func LoadPolicy(data []byte) (*Policy, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	policy := &Policy{}
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWrongPolicyValue, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// validate returns an error wrapping [ErrWrongPolicyValue] if a rule of the policy is invalid,
+// and compiles the regular expressions of the conditions otherwise.
+func (p *Policy) validate() error {
+	p.Rules = append([]PolicyRule(nil), p.Rules...)
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !isResponseAction(rule.Action) {
+			return fmt.Errorf("%w: unknown action %q in rule %q", ErrWrongPolicyValue, rule.Action, rule.Name)
+		}
+		for _, action := range rule.Conditions.Actions {
+			if !isResponseAction(action) {
+				return fmt.Errorf("%w: unknown action %q in rule %q", ErrWrongPolicyValue, action, rule.Name)
+			}
+		}
+		rule.Conditions.headerPatterns = make(map[string]*regexp.Regexp, len(rule.Conditions.Headers))
+		for field, pattern := range rule.Conditions.Headers {
+			if !hasJSONField(reflect.TypeOf(Header{}), field) {
+				return fmt.Errorf("%w: unknown header field %q in rule %q", ErrWrongPolicyValue, field, rule.Name)
+			}
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%w: invalid pattern for header field %q in rule %q", ErrWrongPolicyValue, field, rule.Name)
+			}
+			rule.Conditions.headerPatterns[field] = compiled
+		}
+	}
+	return nil
+}
+
+// evaluate returns the first rule whose conditions are met, or nil if there is none.
+func (p *Policy) evaluate(input *policyInput) *PolicyRule {
+	for i := range p.Rules {
+		if p.Rules[i].Conditions.match(input) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+// match returns whether all the conditions are met.
+func (pc *PolicyConditions) match(input *policyInput) bool {
+	response := input.response
+	if len(pc.Actions) > 0 && !containsValue(pc.Actions, response.Action) {
+		return false
+	}
+	if pc.MinScore != nil && (response.Score == nil || *response.Score < *pc.MinScore) {
+		return false
+	}
+	if pc.MaxScore != nil && (response.Score == nil || *response.Score > *pc.MaxScore) {
+		return false
+	}
+	if len(pc.Reasons) > 0 && !containsAny(pc.Reasons, response.Reasons) {
+		return false
+	}
+	if len(pc.CountryCodes) > 0 {
+		if response.Location == nil || response.Location.CountryCode == nil || !containsValue(pc.CountryCodes, *response.Location.CountryCode) {
+			return false
+		}
+	}
+	if len(pc.Events) > 0 && !containsValue(pc.Events, input.event) {
+		return false
+	}
+	if len(pc.Accounts) > 0 && !containsValue(pc.Accounts, input.account) {
+		return false
+	}
+	for field := range pc.Headers {
+		// the patterns are compiled by the validation of the policy: the conditions are not met otherwise
+		pattern, ok := pc.headerPatterns[field]
+		if !ok || input.header == nil || !pattern.MatchString(headerFieldValue(input.header, field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyPolicy replaces the action of the successful response according to the [Policy] of the [Client], if any.
+func (c *Client) applyPolicy(response *ResponsePayload, event Action, account string, header *Header) {
+	if c.Policy == nil {
+		return
+	}
+	rule := c.Policy.evaluate(&policyInput{
+		response: response,
+		event:    event,
+		account:  account,
+		header:   header,
+	})
+	if rule == nil {
+		return
+	}
+	response.Policy = &PolicyDecision{
+		Policy:         c.Policy.Name,
+		Rule:           rule.Name,
+		OriginalAction: response.Action,
+	}
+	response.Action = rule.Action
+}
+
+// headerFieldValue returns the string representation of the field of the [Header] identified by its JSON name.
+// It returns an empty string for the unset fields.
+func headerFieldValue(header *Header, name string) string {
+	value := reflect.ValueOf(header).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if strings.Split(value.Type().Field(i).Tag.Get("json"), ",")[0] != name {
+			continue
+		}
+		field := value.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				return ""
+			}
+			field = field.Elem()
+		}
+		switch field.Kind() {
+		case reflect.String:
+			return field.String()
+		case reflect.Int:
+			return strconv.FormatInt(field.Int(), 10)
+		}
+		return ""
+	}
+	return ""
+}
+
+// isResponseAction returns whether the action is one of the [ResponseAction] constants.
+func isResponseAction(action ResponseAction) bool {
+	return containsValue([]ResponseAction{Allow, Challenge, Deny, Review}, action)
+}
+
+// containsValue returns whether the value is one of the values.
+func containsValue[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny returns whether one of the candidates is one of the values.
+func containsAny[T comparable](values []T, candidates []T) bool {
+	for _, candidate := range candidates {
+		if containsValue(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
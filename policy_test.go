@@ -0,0 +1,199 @@
+package fraudsdkgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `{
+  "name": "default",
+  "rules": [
+    {"name": "qa-accounts", "when": {"actions": ["deny", "challenge"], "accounts": ["qa@example.com"]}, "action": "allow"},
+    {"name": "high-score", "when": {"actions": ["allow"], "minScore": 80}, "action": "challenge"},
+    {"name": "credential-stuffing", "when": {"reasons": ["credential_stuffing"], "events": ["login"]}, "action": "deny"},
+    {"name": "curl-from-fr", "when": {"countryCodes": ["FR"], "headers": {"userAgent": "^curl/"}}, "action": "review"}
+  ]
+}`
+
+func TestLoadPolicy(t *testing.T) {
+	t.Run("Valid policy", func(t *testing.T) {
+		policy, err := LoadPolicy([]byte(testPolicy))
+		assert.Nil(t, err)
+		assert.Equal(t, "default", policy.Name)
+		assert.Len(t, policy.Rules, 4)
+		assert.Equal(t, 80, *policy.Rules[1].Conditions.MinScore)
+	})
+
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{"Invalid JSON", `{"rules": [`},
+		{"Unknown field", `{"rules": [{"name": "r", "when": {"score": 80}, "action": "deny"}]}`},
+		{"Unknown action", `{"rules": [{"name": "r", "when": {}, "action": "block"}]}`},
+		{"Unknown condition action", `{"rules": [{"name": "r", "when": {"actions": ["block"]}, "action": "deny"}]}`},
+		{"Unknown header field", `{"rules": [{"name": "r", "when": {"headers": {"unknown": "x"}}, "action": "deny"}]}`},
+		{"Invalid header pattern", `{"rules": [{"name": "r", "when": {"headers": {"userAgent": "("}}, "action": "deny"}]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadPolicy([]byte(tc.document))
+			assert.ErrorIs(t, err, ErrWrongPolicyValue)
+		})
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	policy, err := LoadPolicy([]byte(testPolicy))
+	assert.Nil(t, err)
+
+	score := func(value int) *int { return &value }
+	fr := "FR"
+
+	tests := []struct {
+		name     string
+		response SuccessResponsePayload
+		event    Action
+		account  string
+		header   Header
+		want     string
+	}{
+		{"Test account denied", SuccessResponsePayload{Action: Deny}, Login, "qa@example.com", Header{}, "qa-accounts"},
+		{"Other account denied", SuccessResponsePayload{Action: Deny}, Login, "john@example.com", Header{}, ""},
+		{"Allowed with high score", SuccessResponsePayload{Action: Allow, Score: score(85)}, Registration, "john@example.com", Header{}, "high-score"},
+		{"Allowed with low score", SuccessResponsePayload{Action: Allow, Score: score(20)}, Registration, "john@example.com", Header{}, ""},
+		{"Allowed without score", SuccessResponsePayload{Action: Allow}, Registration, "john@example.com", Header{}, ""},
+		{"Reason on login", SuccessResponsePayload{Action: Review, Reasons: []string{"new_device", "credential_stuffing"}}, Login, "john@example.com", Header{}, "credential-stuffing"},
+		{"Reason on registration", SuccessResponsePayload{Action: Review, Reasons: []string{"credential_stuffing"}}, Registration, "john@example.com", Header{}, ""},
+		{"Country and header", SuccessResponsePayload{Action: Allow, Location: &Location{CountryCode: &fr}}, Login, "john@example.com", Header{UserAgent: "curl/8.0"}, "curl-from-fr"},
+		{"Country without header", SuccessResponsePayload{Action: Allow, Location: &Location{CountryCode: &fr}}, Login, "john@example.com", Header{UserAgent: "Mozilla/5.0"}, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := policy.evaluate(&policyInput{
+				response: &ResponsePayload{SuccessResponsePayload: tc.response},
+				event:    tc.event,
+				account:  tc.account,
+				header:   &tc.header,
+			})
+			if tc.want == "" {
+				assert.Nil(t, rule)
+			} else if assert.NotNil(t, rule) {
+				assert.Equal(t, tc.want, rule.Name)
+			}
+		})
+	}
+}
+
+// describedEvent is a custom [Event] returning the same recommendation for all the accounts.
+type describedEvent struct {
+	account string
+}
+
+func (e *describedEvent) Describe() (string, Action) {
+	return e.account, Login
+}
+
+func (e *describedEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	return &ResponsePayload{SuccessResponsePayload: SuccessResponsePayload{Action: Deny, Status: OK}}, nil
+}
+
+func (e *describedEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	return nil, nil
+}
+
+func TestClientWithPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"action":"allow","score":90}`))
+	}))
+	defer server.Close()
+
+	t.Run("Invalid policy", func(t *testing.T) {
+		_, err := NewClient("your-api-key", ClientWithPolicy(Policy{Rules: []PolicyRule{{Name: "r", Action: "block"}}}))
+		assert.ErrorIs(t, err, ErrWrongPolicyValue)
+	})
+
+	t.Run("Fired rule is reported", func(t *testing.T) {
+		policy, err := LoadPolicy([]byte(testPolicy))
+		assert.Nil(t, err)
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL), ClientWithPolicy(*policy))
+		assert.Nil(t, err)
+
+		resp, err := c.Validate(setupRequest(), NewLoginEvent("john@example.com", Succeeded))
+		assert.Nil(t, err)
+		assert.Equal(t, Challenge, resp.Action)
+		assert.Equal(t, OK, resp.Status)
+		assert.Equal(t, &PolicyDecision{Policy: "default", Rule: "high-score", OriginalAction: Allow}, resp.Policy)
+	})
+
+	t.Run("Custom event", func(t *testing.T) {
+		policy, err := LoadPolicy([]byte(testPolicy))
+		assert.Nil(t, err)
+		c, err := NewClient("your-api-key", ClientWithPolicy(*policy))
+		assert.Nil(t, err)
+
+		resp, err := c.Validate(setupRequest(), &describedEvent{account: "qa@example.com"})
+		assert.Nil(t, err)
+		assert.Equal(t, Allow, resp.Action)
+		assert.Equal(t, "qa-accounts", resp.Policy.Rule)
+
+		addr, method := "203.0.113.1", http.MethodPost
+		resp, err = c.ValidateFromMetadata(context.Background(), &describedEvent{account: "qa@example.com"}, &RequestMetadata{Addr: &addr, Method: &method})
+		assert.Nil(t, err)
+		assert.Equal(t, Allow, resp.Action)
+		assert.Equal(t, "qa-accounts", resp.Policy.Rule)
+
+		resp, err = c.Validate(setupRequest(), &describedEvent{account: "john@example.com"})
+		assert.Nil(t, err)
+		assert.Equal(t, Deny, resp.Action)
+		assert.Nil(t, resp.Policy)
+	})
+
+	t.Run("Policy set without validation", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+		assert.Nil(t, err)
+		c.Policy = &Policy{Rules: []PolicyRule{
+			{Name: "curl", Conditions: PolicyConditions{Headers: map[string]string{"userAgent": "^curl/"}}, Action: Deny},
+		}}
+
+		resp, err := c.Validate(setupRequest(), NewLoginEvent("john@example.com", Succeeded))
+		assert.Nil(t, err)
+		assert.Equal(t, Allow, resp.Action)
+		assert.Nil(t, resp.Policy)
+	})
+
+	t.Run("Without policy", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL))
+		assert.Nil(t, err)
+
+		resp, err := c.Validate(setupRequest(), NewLoginEvent("john@example.com", Succeeded))
+		assert.Nil(t, err)
+		assert.Equal(t, Allow, resp.Action)
+		assert.Nil(t, resp.Policy)
+	})
+}
+
+func ExampleLoadPolicy() {
+	policy, err := LoadPolicy([]byte(`{
+		"name": "default",
+		"rules": [{"name": "high-score", "when": {"actions": ["allow"], "minScore": 80}, "action": "challenge"}]
+	}`))
+	if err != nil {
+		return
+	}
+
+	c, err := NewClient("your-api-key", ClientWithPolicy(*policy))
+	if err != nil {
+		return
+	}
+
+	fmt.Println(c.Policy.Rules[0].Name, c.Policy.Rules[0].Action)
+	// Output: high-score challenge
+}
@@ -0,0 +1,132 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PromoCodeOption describes the functional option signature to customize the [PromoCodeEvent] behavior.
+type PromoCodeOption func(*PromoCodeEvent)
+
+// PromoCodeWithSession is a functional option to set the [Session] field.
+func PromoCodeWithSession(session Session) PromoCodeOption {
+	return func(e *PromoCodeEvent) {
+		e.Session = &session
+	}
+}
+
+// PromoCodeWithUser is a functional option to set the [User] field.
+func PromoCodeWithUser(user User) PromoCodeOption {
+	return func(e *PromoCodeEvent) {
+		e.User = &user
+	}
+}
+
+// PromoCodeWithValue is a functional option to set the monetary value granted by the promo code.
+func PromoCodeWithValue(value MonetaryValue) PromoCodeOption {
+	return func(e *PromoCodeEvent) {
+		e.Value = &value
+	}
+}
+
+// NewPromoCodeEvent instantiates a new [PromoCodeEvent] that implements the [Event] interface.
+func NewPromoCodeEvent(account string, code string, options ...PromoCodeOption) *PromoCodeEvent {
+	event := &PromoCodeEvent{
+		Account: account,
+		Action:  PromoCodeRedemption,
+		Code:    code,
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(event)
+	}
+
+	return event
+}
+
+// Check returns the problems found in the fields of the [PromoCodeEvent] (see [Checker]).
+func (e *PromoCodeEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	pc.required("code", e.Code)
+	pc.monetaryValue("value", e.Value)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [PromoCodeEvent] (see [Describer]).
+func (e *PromoCodeEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
+// buildRequestPayload is used to construct the [PromoCodeRequestPayload] based on the information stored
+// in the [PromoCodeEvent] structure.
+func (e *PromoCodeEvent) buildRequestPayload(module *Module, header *Header) *PromoCodeRequestPayload {
+	return &PromoCodeRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: e.Account,
+			Header:  *header,
+			Module:  *module,
+		},
+		Code:    e.Code,
+		Session: e.Session,
+		User:    e.User,
+		Value:   e.Value,
+	}
+}
+
+// Validate is used to construct the [PromoCodeRequestPayload] based on the information stored
+// in the [PromoCodeEvent] structure and performs the validation request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *PromoCodeEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/validate/promotion/code", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		resp := &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+			},
+		}
+		if errors.Is(err, ErrRequestTimeout) {
+			resp.Status = Timeout
+		} else {
+			resp.Status = Failure
+		}
+		return resp, fmt.Errorf("fail to validate promo code request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		return handleErrorResponse(responsePayload), nil
+	}
+	resp, err := decodeResponse[ResponsePayload](responsePayload)
+	if err != nil {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Failure,
+			},
+		}, err
+	}
+	resp.Status = OK
+	return resp, nil
+}
+
+// Collect is used to construct the [PromoCodeRequestPayload] based on the information stored
+// in the [PromoCodeEvent] structure and performs the enrichment request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *PromoCodeEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/collect/promotion/code", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("fail to collect promo code request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		responsePayload := handleErrorResponse(responsePayload)
+		return &responsePayload.ErrorResponsePayload, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoCodeWithSession(t *testing.T) {
+	sessionID := "123456"
+	session := Session{
+		ID: &sessionID,
+	}
+
+	event := NewPromoCodeEvent("test-account", "WELCOME10", PromoCodeWithSession(session))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Session)
+	assert.Equal(t, sessionID, *event.Session.ID)
+}
+
+func TestPromoCodeWithUser(t *testing.T) {
+	event := NewPromoCodeEvent("test-account", "WELCOME10", PromoCodeWithUser(User{ID: "123456"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.User)
+	assert.Equal(t, "123456", event.User.ID)
+}
+
+func TestPromoCodeWithValue(t *testing.T) {
+	event := NewPromoCodeEvent("test-account", "WELCOME10", PromoCodeWithValue(MonetaryValue{Amount: 1000, Currency: "EUR"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Value)
+	assert.Equal(t, int64(1000), event.Value.Amount)
+	assert.Equal(t, "EUR", event.Value.Currency)
+}
+
+func TestNewPromoCodeEvent(t *testing.T) {
+	event := NewPromoCodeEvent("test-account", "WELCOME10")
+	assert.NotNil(t, event)
+	assert.Equal(t, "test-account", event.Account)
+	assert.Equal(t, PromoCodeRedemption, event.Action)
+	assert.Equal(t, "WELCOME10", event.Code)
+}
+
+func ExamplePromoCodeWithValue() {
+	event := NewPromoCodeEvent("test-account", "WELCOME10", PromoCodeWithValue(MonetaryValue{Amount: 1000, Currency: "EUR"}))
+
+	fmt.Println(event.Value.Amount, event.Value.Currency)
+	// Output: 1000 EUR
+}
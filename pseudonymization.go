@@ -0,0 +1,97 @@
+package fraudsdkgo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// pseudonymizePayload replaces the personal information of the [User] of the payload, and the contact points
+// of the [ContactUpdateRequestPayload], by their pseudonymized values (see [Client.pseudonymizeUser]).
+// The payload is left untouched if no pseudonymization key is configured on the [Client].
+func pseudonymizePayload[T AllowedRequestPayload](c *Client, payload *T) {
+	if len(c.PseudonymizationKey) == 0 {
+		return
+	}
+	switch p := any(payload).(type) {
+	case *LoginRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	case *RegistrationRequestPayload:
+		p.User = *c.pseudonymizeUser(&p.User)
+	case *AccountUpdateRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	case *PasswordUpdateRequestPayload:
+		p.User = *c.pseudonymizeUser(&p.User)
+	case *ContactUpdateRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+		c.pseudonymizeContactValues(p)
+	case *PaymentMethodRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	case *SessionRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	case *PromoCodeRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	case *ReferralRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	case *LoyaltyRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	case *ContentRequestPayload:
+		p.User = c.pseudonymizeUser(p.User)
+	}
+}
+
+// pseudonymizeUser returns a copy of the [User] where the Email, the Phone, the NormalizedEmail,
+// the NormalizedPhone, the FirstName, the LastName and the Name, Line1 and Line2 fields of the [Address]
+// are replaced by the keyed HMAC-SHA256 of their normalized values. The original [User] is not modified.
+func (c *Client) pseudonymizeUser(user *User) *User {
+	if user == nil {
+		return nil
+	}
+	pseudonymized := *user
+	pseudonymized.Email = c.pseudonymize(user.Email, normalizeEmailValue)
+	pseudonymized.Phone = c.pseudonymize(user.Phone, normalizePhoneValue)
+	pseudonymized.FirstName = c.pseudonymize(user.FirstName, normalizeTextValue)
+	pseudonymized.LastName = c.pseudonymize(user.LastName, normalizeTextValue)
+	pseudonymized.NormalizedEmail = c.pseudonymize(user.NormalizedEmail, normalizeEmailValue)
+	pseudonymized.NormalizedPhone = c.pseudonymize(user.NormalizedPhone, normalizePhoneValue)
+	if user.Address != nil {
+		address := *user.Address
+		address.Line1 = c.pseudonymize(user.Address.Line1, normalizeTextValue)
+		address.Line2 = c.pseudonymize(user.Address.Line2, normalizeTextValue)
+		address.Name = c.pseudonymize(user.Address.Name, normalizeTextValue)
+		pseudonymized.Address = &address
+	}
+	return &pseudonymized
+}
+
+// pseudonymizeContactValues replaces the non-empty previous and new values of the payload by the keyed
+// HMAC-SHA256 of their normalized values, or of their SHA-256 hashes when they are already hashed
+// (see [ContactUpdateWithHashedValues]). The values are then flagged as hashed.
+func (c *Client) pseudonymizeContactValues(p *ContactUpdateRequestPayload) {
+	normalize := normalizePhoneValue
+	if p.ContactType == EmailContactType {
+		normalize = normalizeEmailValue
+	}
+	if p.Hashed {
+		normalize = func(value string) string { return value }
+	}
+	if p.PreviousValue != "" {
+		p.PreviousValue = *c.pseudonymize(&p.PreviousValue, normalize)
+	}
+	if p.NewValue != "" {
+		p.NewValue = *c.pseudonymize(&p.NewValue, normalize)
+	}
+	p.Hashed = true
+}
+
+// pseudonymize returns the hex-encoded HMAC-SHA256 of the normalized value, keyed with the
+// pseudonymization key of the [Client]. It returns nil if the value is nil.
+func (c *Client) pseudonymize(value *string, normalize func(string) string) *string {
+	if value == nil {
+		return nil
+	}
+	mac := hmac.New(sha256.New, c.PseudonymizationKey)
+	mac.Write([]byte(normalize(*value)))
+	pseudonymized := hex.EncodeToString(mac.Sum(nil))
+	return &pseudonymized
+}
@@ -0,0 +1,169 @@
+package fraudsdkgo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func expectedPseudonym(key string, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func setupPersonalUser() User {
+	email := " John.Doe@Example.com "
+	phone := "+33 (0)6-12.34.56.78"
+	firstName := "  John "
+	lastName := "DOE"
+	line1 := "1  Main   Street"
+	city := "Paris"
+	return User{
+		ID:        "123456",
+		Email:     &email,
+		Phone:     &phone,
+		FirstName: &firstName,
+		LastName:  &lastName,
+		Address: &Address{
+			City:  &city,
+			Line1: &line1,
+		},
+	}
+}
+
+func TestPseudonymizeUser(t *testing.T) {
+	c, err := NewClient("your-api-key", ClientWithPseudonymization([]byte("secret")))
+	assert.Nil(t, err)
+
+	user := setupPersonalUser()
+	got := c.pseudonymizeUser(&user)
+
+	assert.Equal(t, "123456", got.ID)
+	assert.Equal(t, expectedPseudonym("secret", "john.doe@example.com"), *got.Email)
+	assert.Equal(t, expectedPseudonym("secret", "+330612345678"), *got.Phone)
+	assert.Equal(t, expectedPseudonym("secret", "john"), *got.FirstName)
+	assert.Equal(t, expectedPseudonym("secret", "doe"), *got.LastName)
+	assert.Equal(t, expectedPseudonym("secret", "1 main street"), *got.Address.Line1)
+	assert.Nil(t, got.Address.Line2)
+	assert.Equal(t, "Paris", *got.Address.City)
+
+	// the original user is not modified
+	assert.Equal(t, " John.Doe@Example.com ", *user.Email)
+	assert.Equal(t, "1  Main   Street", *user.Address.Line1)
+
+	assert.Nil(t, c.pseudonymizeUser(nil))
+}
+
+func TestPseudonymizePayload_WithoutKey(t *testing.T) {
+	c, err := NewClient("your-api-key")
+	assert.Nil(t, err)
+
+	payload := &RegistrationRequestPayload{User: setupPersonalUser()}
+	pseudonymizePayload(c, payload)
+	assert.Equal(t, " John.Doe@Example.com ", *payload.User.Email)
+}
+
+func TestWithPseudonymization(t *testing.T) {
+	t.Run("Applies to every event with a user", func(t *testing.T) {
+		var bodies []map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var payload map[string]interface{}
+			assert.Nil(t, json.Unmarshal(body, &payload))
+			bodies = append(bodies, payload)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"action":"allow"}`))
+		}))
+		defer server.Close()
+
+		c, err := NewClient("your-api-key", ClientWithEndpoint(server.URL), ClientWithPseudonymization([]byte("secret")))
+		assert.Nil(t, err)
+
+		user := setupPersonalUser()
+		events := []Event{
+			NewLoginEvent("test-account", Succeeded, LoginWithUser(user)),
+			NewRegistrationEvent("test-account", user),
+			NewAccountUpdateEvent("test-account", AccountUpdateWithUser(user)),
+			NewPasswordUpdateEvent("test-account", user, UserUpdate, PasswordUpdateSucceeded),
+		}
+		for _, event := range events {
+			_, err := c.Validate(setupRequest(), event)
+			assert.Nil(t, err)
+		}
+
+		assert.Len(t, bodies, len(events))
+		for _, body := range bodies {
+			sent := body["user"].(map[string]interface{})
+			assert.Equal(t, expectedPseudonym("secret", "john.doe@example.com"), sent["email"])
+			assert.Equal(t, expectedPseudonym("secret", "+330612345678"), sent["phone"])
+			assert.Equal(t, "123456", sent["id"])
+		}
+		assert.Equal(t, " John.Doe@Example.com ", *user.Email)
+	})
+
+	t.Run("Applies to the contact points", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithPseudonymization([]byte("secret")))
+		assert.Nil(t, err)
+
+		tests := []struct {
+			name         string
+			event        *ContactUpdateEvent
+			wantPrevious string
+			wantNew      string
+		}{
+			{
+				"Raw email",
+				NewContactUpdateEvent("test-account", EmailContactType, " Old@Example.com", "new@example.com", ContactUpdateRequested),
+				expectedPseudonym("secret", "old@example.com"),
+				expectedPseudonym("secret", "new@example.com"),
+			},
+			{
+				"Raw phone without previous value",
+				NewContactUpdateEvent("test-account", PhoneContactType, "", "+33 6 12 34 56 78", ContactUpdateVerified),
+				"",
+				expectedPseudonym("secret", "+33612345678"),
+			},
+			{
+				"Hashed email",
+				NewContactUpdateEvent("test-account", EmailContactType, "old@example.com", "new@example.com", ContactUpdateRequested, ContactUpdateWithHashedValues()),
+				expectedPseudonym("secret", hashValue("old@example.com")),
+				expectedPseudonym("secret", hashValue("new@example.com")),
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				payload := tc.event.buildRequestPayload(&Module{}, &Header{})
+				pseudonymizePayload(c, payload)
+				assert.Equal(t, tc.wantPrevious, payload.PreviousValue)
+				assert.Equal(t, tc.wantNew, payload.NewValue)
+				assert.True(t, payload.Hashed)
+			})
+		}
+	})
+
+	t.Run("With an empty key", func(t *testing.T) {
+		c, err := NewClient("your-api-key", ClientWithPseudonymization([]byte{}))
+		assert.Nil(t, c)
+		assert.Equal(t, ErrWrongPseudonymizationKeyValue, err)
+	})
+}
+
+func ExampleClientWithPseudonymization() {
+	c, _ := NewClient("your-api-key", ClientWithPseudonymization([]byte("secret")))
+
+	email := "John.Doe@Example.com"
+	user := c.pseudonymizeUser(&User{ID: "123456", Email: &email})
+
+	fmt.Println(len(*user.Email))
+	// Output: 64
+}
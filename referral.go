@@ -0,0 +1,136 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ReferralOption describes the functional option signature to customize the [ReferralEvent] behavior.
+type ReferralOption func(*ReferralEvent)
+
+// ReferralWithSession is a functional option to set the [Session] field.
+func ReferralWithSession(session Session) ReferralOption {
+	return func(e *ReferralEvent) {
+		e.Session = &session
+	}
+}
+
+// ReferralWithUser is a functional option to set the [User] field.
+func ReferralWithUser(user User) ReferralOption {
+	return func(e *ReferralEvent) {
+		e.User = &user
+	}
+}
+
+// ReferralWithValue is a functional option to set the monetary value of the referral bonus.
+func ReferralWithValue(value MonetaryValue) ReferralOption {
+	return func(e *ReferralEvent) {
+		e.Value = &value
+	}
+}
+
+// NewReferralEvent instantiates a new [ReferralEvent] that implements the [Event] interface.
+// The referrerAccount is the account that owns the referral code used by the account.
+func NewReferralEvent(account string, code string, referrerAccount string, options ...ReferralOption) *ReferralEvent {
+	event := &ReferralEvent{
+		Account:         account,
+		Action:          Referral,
+		Code:            code,
+		ReferrerAccount: referrerAccount,
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(event)
+	}
+
+	return event
+}
+
+// Check returns the problems found in the fields of the [ReferralEvent] (see [Checker]).
+func (e *ReferralEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	pc.required("code", e.Code)
+	pc.required("referrerAccount", e.ReferrerAccount)
+	pc.monetaryValue("value", e.Value)
+	pc.user("user", e.User)
+	pc.session("session", e.Session)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [ReferralEvent] (see [Describer]).
+func (e *ReferralEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
+// buildRequestPayload is used to construct the [ReferralRequestPayload] based on the information stored
+// in the [ReferralEvent] structure.
+func (e *ReferralEvent) buildRequestPayload(module *Module, header *Header) *ReferralRequestPayload {
+	return &ReferralRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: e.Account,
+			Header:  *header,
+			Module:  *module,
+		},
+		Code:            e.Code,
+		ReferrerAccount: e.ReferrerAccount,
+		Session:         e.Session,
+		User:            e.User,
+		Value:           e.Value,
+	}
+}
+
+// Validate is used to construct the [ReferralRequestPayload] based on the information stored
+// in the [ReferralEvent] structure and performs the validation request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *ReferralEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/validate/referral", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		resp := &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+			},
+		}
+		if errors.Is(err, ErrRequestTimeout) {
+			resp.Status = Timeout
+		} else {
+			resp.Status = Failure
+		}
+		return resp, fmt.Errorf("fail to validate referral request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		return handleErrorResponse(responsePayload), nil
+	}
+	resp, err := decodeResponse[ResponsePayload](responsePayload)
+	if err != nil {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Failure,
+			},
+		}, err
+	}
+	resp.Status = OK
+	return resp, nil
+}
+
+// Collect is used to construct the [ReferralRequestPayload] based on the information stored
+// in the [ReferralEvent] structure and performs the enrichment request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *ReferralEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/collect/referral", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("fail to collect referral request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		responsePayload := handleErrorResponse(responsePayload)
+		return &responsePayload.ErrorResponsePayload, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,51 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferralWithSession(t *testing.T) {
+	sessionID := "123456"
+	session := Session{
+		ID: &sessionID,
+	}
+
+	event := NewReferralEvent("test-account", "FRIEND-42", "referrer-account", ReferralWithSession(session))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Session)
+	assert.Equal(t, sessionID, *event.Session.ID)
+}
+
+func TestReferralWithUser(t *testing.T) {
+	event := NewReferralEvent("test-account", "FRIEND-42", "referrer-account", ReferralWithUser(User{ID: "123456"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.User)
+	assert.Equal(t, "123456", event.User.ID)
+}
+
+func TestReferralWithValue(t *testing.T) {
+	event := NewReferralEvent("test-account", "FRIEND-42", "referrer-account", ReferralWithValue(MonetaryValue{Amount: 550, Currency: "USD"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Value)
+	assert.Equal(t, int64(550), event.Value.Amount)
+	assert.Equal(t, "USD", event.Value.Currency)
+}
+
+func TestNewReferralEvent(t *testing.T) {
+	event := NewReferralEvent("test-account", "FRIEND-42", "referrer-account")
+	assert.NotNil(t, event)
+	assert.Equal(t, "test-account", event.Account)
+	assert.Equal(t, Referral, event.Action)
+	assert.Equal(t, "FRIEND-42", event.Code)
+	assert.Equal(t, "referrer-account", event.ReferrerAccount)
+}
+
+func ExampleNewReferralEvent() {
+	event := NewReferralEvent("test-account", "FRIEND-42", "referrer-account")
+
+	fmt.Println(event.ReferrerAccount)
+	// Output: referrer-account
+}
@@ -41,6 +41,21 @@ func NewRegistrationEvent(account string, user User, options ...RegistrationEven
 	return event
 }
 
+// Check returns the problems found in the fields of the [RegistrationEvent] (see [Checker]).
+func (e *RegistrationEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	pc.user("user", &e.User)
+	pc.session("session", e.Session)
+	pc.authentication("authentication", e.Authentication)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [RegistrationEvent] (see [Describer]).
+func (e *RegistrationEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
 // Validate is used to construct the [RegistrationRequestPayload] based on the information stored
 // in the [RegistrationEvent] structure and performs the validation request to the Account Protect API.
 // An error may be returned in case of error when performing the request.
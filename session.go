@@ -0,0 +1,143 @@
+package fraudsdkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SessionOption describes the functional option signature to customize the [SessionEvent] behavior.
+type SessionOption func(*SessionEvent)
+
+// SessionWithAuthentication is a functional option to set the [Authentication] field.
+func SessionWithAuthentication(authentication Authentication) SessionOption {
+	return func(e *SessionEvent) {
+		e.Authentication = &authentication
+	}
+}
+
+// SessionWithCreatedAt is a functional option to set the creation date of the token of the [Session].
+func SessionWithCreatedAt(createdAt time.Time) SessionOption {
+	return func(e *SessionEvent) {
+		e.Session.SetCreatedAt(createdAt)
+	}
+}
+
+// SessionWithExpiresAt is a functional option to set the expiry date of the token of the [Session].
+func SessionWithExpiresAt(expiresAt time.Time) SessionOption {
+	return func(e *SessionEvent) {
+		e.Session.SetExpiresAt(expiresAt)
+	}
+}
+
+// SessionWithUser is a functional option to set the [User] field.
+func SessionWithUser(user User) SessionOption {
+	return func(e *SessionEvent) {
+		e.User = &user
+	}
+}
+
+// NewSessionEvent instantiates a new [SessionEvent] that implements the [Event] interface.
+// The [Session] should describe the token concerned by the event, ideally with its expiry,
+// its token family identifier, and the identifier of the client application it was issued to.
+func NewSessionEvent(account string, status SessionTokenStatus, session Session, options ...SessionOption) *SessionEvent {
+	event := &SessionEvent{
+		Account: account,
+		Action:  SessionToken,
+		Session: session,
+		Status:  status,
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(event)
+	}
+
+	return event
+}
+
+// Check returns the problems found in the fields of the [SessionEvent] (see [Checker]).
+func (e *SessionEvent) Check() []ErrorInfo {
+	var pc payloadChecker
+	pc.required("account", e.Account)
+	checkOneOf(&pc, "status", e.Status, SessionTokenIssued, SessionTokenRefreshed, SessionTokenRevoked, SessionTokenReusedAfterRevoke)
+	pc.session("session", &e.Session)
+	pc.user("user", e.User)
+	pc.authentication("authentication", e.Authentication)
+	return pc.result()
+}
+
+// Describe returns the account and the type of the [SessionEvent] (see [Describer]).
+func (e *SessionEvent) Describe() (string, Action) {
+	return e.Account, e.Action
+}
+
+// buildRequestPayload is used to construct the [SessionRequestPayload] based on the information stored
+// in the [SessionEvent] structure.
+func (e *SessionEvent) buildRequestPayload(module *Module, header *Header) *SessionRequestPayload {
+	return &SessionRequestPayload{
+		CommonRequestPayload: CommonRequestPayload{
+			Account: e.Account,
+			Header:  *header,
+			Module:  *module,
+		},
+		Authentication: e.Authentication,
+		Session:        e.Session,
+		Status:         e.Status,
+		User:           e.User,
+	}
+}
+
+// Validate is used to construct the [SessionRequestPayload] based on the information stored
+// in the [SessionEvent] structure and performs the validation request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *SessionEvent) Validate(c *Client, r *http.Request, module *Module, header *Header) (*ResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/validate/session", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		resp := &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+			},
+		}
+		if errors.Is(err, ErrRequestTimeout) {
+			resp.Status = Timeout
+		} else {
+			resp.Status = Failure
+		}
+		return resp, fmt.Errorf("fail to validate session request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		return handleErrorResponse(responsePayload), nil
+	}
+	resp, err := decodeResponse[ResponsePayload](responsePayload)
+	if err != nil {
+		return &ResponsePayload{
+			SuccessResponsePayload: SuccessResponsePayload{
+				Action: Allow,
+				Status: Failure,
+			},
+		}, err
+	}
+	resp.Status = OK
+	return resp, nil
+}
+
+// Collect is used to construct the [SessionRequestPayload] based on the information stored
+// in the [SessionEvent] structure and performs the enrichment request to the Account Protect API.
+// An error may be returned in case of error when performing the request.
+func (e *SessionEvent) Collect(c *Client, r *http.Request, module *Module, header *Header) (*ErrorResponsePayload, error) {
+	requestPayload := e.buildRequestPayload(module, header)
+	endpoint := fmt.Sprintf("%s/v1/collect/session", c.Endpoint)
+	responseStatusCode, responsePayload, err := performRequest(r.Context(), c, endpoint, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("fail to collect session request: %w", err)
+	}
+	if !(responseStatusCode >= 200 && responseStatusCode < 300) {
+		responsePayload := handleErrorResponse(responsePayload)
+		return &responsePayload.ErrorResponsePayload, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+package fraudsdkgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSession() Session {
+	sessionID := "123456"
+	clientApplicationID := "ios-app"
+	createdAt := "1970-01-01T00:00:00Z"
+	expiresAt := "1970-01-01T01:00:00Z"
+	tokenFamilyID := "family-1"
+	return Session{
+		ID:                  &sessionID,
+		ClientApplicationID: &clientApplicationID,
+		CreatedAt:           &createdAt,
+		ExpiresAt:           &expiresAt,
+		TokenFamilyID:       &tokenFamilyID,
+	}
+}
+
+func TestSessionWithAuthentication(t *testing.T) {
+	authenticationMode := OTP
+	authentication := Authentication{
+		Mode: &authenticationMode,
+	}
+
+	event := NewSessionEvent("test-account", SessionTokenIssued, setupSession(), SessionWithAuthentication(authentication))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.Authentication)
+	assert.Equal(t, authenticationMode, *event.Authentication.Mode)
+}
+
+func TestSessionWithCreatedAt(t *testing.T) {
+	createdAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	event := NewSessionEvent("test-account", SessionTokenIssued, Session{}, SessionWithCreatedAt(createdAt))
+	assert.NotNil(t, event)
+	assert.Equal(t, "2023-12-31T23:00:00Z", *event.Session.CreatedAt)
+	got, err := event.Session.CreatedAtTime()
+	assert.Nil(t, err)
+	assert.True(t, createdAt.Equal(got))
+}
+
+func TestSessionWithExpiresAt(t *testing.T) {
+	expiresAt := time.Date(2024, time.January, 1, 1, 0, 0, 0, time.UTC)
+
+	event := NewSessionEvent("test-account", SessionTokenIssued, setupSession(), SessionWithExpiresAt(expiresAt))
+	assert.NotNil(t, event)
+	assert.Equal(t, "2024-01-01T01:00:00Z", *event.Session.ExpiresAt)
+	assert.Equal(t, "1970-01-01T00:00:00Z", *event.Session.CreatedAt)
+}
+
+func TestSessionWithUser(t *testing.T) {
+	event := NewSessionEvent("test-account", SessionTokenRefreshed, setupSession(), SessionWithUser(User{ID: "123456"}))
+	assert.NotNil(t, event)
+	assert.NotNil(t, event.User)
+	assert.Equal(t, "123456", event.User.ID)
+}
+
+func TestNewSessionEvent(t *testing.T) {
+	event := NewSessionEvent("test-account", SessionTokenReusedAfterRevoke, setupSession())
+	assert.NotNil(t, event)
+	assert.Equal(t, "test-account", event.Account)
+	assert.Equal(t, SessionToken, event.Action)
+	assert.Equal(t, SessionTokenReusedAfterRevoke, event.Status)
+	assert.Equal(t, "family-1", *event.Session.TokenFamilyID)
+	assert.Equal(t, "ios-app", *event.Session.ClientApplicationID)
+	assert.Equal(t, "1970-01-01T01:00:00Z", *event.Session.ExpiresAt)
+}
+
+func TestSessionRequestPayload_JSON(t *testing.T) {
+	event := NewSessionEvent("test-account", SessionTokenRevoked, setupSession())
+	body, err := json.Marshal(event.buildRequestPayload(&Module{}, &Header{}))
+	assert.Nil(t, err)
+
+	var payload map[string]interface{}
+	assert.Nil(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "revoked", payload["status"])
+	session := payload["session"].(map[string]interface{})
+	assert.Equal(t, "family-1", session["tokenFamilyId"])
+	assert.Equal(t, "ios-app", session["clientApplicationId"])
+	assert.Equal(t, "1970-01-01T01:00:00Z", session["expiresAt"])
+	assert.NotContains(t, payload, "user")
+}
+
+func ExampleNewSessionEvent() {
+	tokenFamilyID := "family-1"
+	event := NewSessionEvent("test-account", SessionTokenRefreshed, Session{TokenFamilyID: &tokenFamilyID})
+
+	fmt.Println(event.Status)
+	fmt.Println(*event.Session.TokenFamilyID)
+	// Output:
+	// refreshed
+	// family-1
+}
@@ -0,0 +1,60 @@
+package fraudsdkgo
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimestampLayout is the layout of the timestamps expected by the Account Protect API.
+const TimestampLayout = time.RFC3339
+
+// FormatTimestamp returns the timestamp in the format expected by the Account Protect API,
+// i.e. RFC 3339 in UTC (e.g. `2024-01-01T00:00:00Z`).
+// It may be used to set the timestamp fields, e.g. `Session{CreatedAt: FormatTimestamp(t)}`.
+func FormatTimestamp(t time.Time) *string {
+	value := t.UTC().Format(TimestampLayout)
+	return &value
+}
+
+// ParseTimestamp parses a timestamp formatted with [FormatTimestamp] or any other RFC 3339 timestamp.
+// An error wrapping [ErrInvalidTimestamp] is returned if the value is nil or cannot be parsed.
+func ParseTimestamp(value *string) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, fmt.Errorf("%w: missing value", ErrInvalidTimestamp)
+	}
+	t, err := time.Parse(TimestampLayout, *value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimestamp, *value)
+	}
+	return t, nil
+}
+
+// SetCreatedAt sets the CreatedAt field of the [Session] in the format expected by the Account Protect API.
+func (s *Session) SetCreatedAt(t time.Time) {
+	s.CreatedAt = FormatTimestamp(t)
+}
+
+// CreatedAtTime returns the CreatedAt field of the [Session] as a [time.Time] (see [ParseTimestamp]).
+func (s *Session) CreatedAtTime() (time.Time, error) {
+	return ParseTimestamp(s.CreatedAt)
+}
+
+// SetExpiresAt sets the ExpiresAt field of the [Session] in the format expected by the Account Protect API.
+func (s *Session) SetExpiresAt(t time.Time) {
+	s.ExpiresAt = FormatTimestamp(t)
+}
+
+// ExpiresAtTime returns the ExpiresAt field of the [Session] as a [time.Time] (see [ParseTimestamp]).
+func (s *Session) ExpiresAtTime() (time.Time, error) {
+	return ParseTimestamp(s.ExpiresAt)
+}
+
+// SetCreatedAt sets the CreatedAt field of the [User] in the format expected by the Account Protect API.
+func (u *User) SetCreatedAt(t time.Time) {
+	u.CreatedAt = FormatTimestamp(t)
+}
+
+// CreatedAtTime returns the CreatedAt field of the [User] as a [time.Time] (see [ParseTimestamp]).
+func (u *User) CreatedAtTime() (time.Time, error) {
+	return ParseTimestamp(u.CreatedAt)
+}
@@ -0,0 +1,93 @@
+package fraudsdkgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	paris := time.FixedZone("CET", 3600)
+	createdAt := time.Date(2024, time.January, 1, 1, 30, 0, 123456789, paris)
+
+	assert.Equal(t, "2024-01-01T00:30:00Z", *FormatTimestamp(createdAt))
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Time
+	}{
+		{"UTC", "2024-01-01T00:30:00Z", time.Date(2024, time.January, 1, 0, 30, 0, 0, time.UTC)},
+		{"With an offset", "2024-01-01T01:30:00+01:00", time.Date(2024, time.January, 1, 0, 30, 0, 0, time.UTC)},
+		{"With fractional seconds", "2024-01-01T00:30:00.5Z", time.Date(2024, time.January, 1, 0, 30, 0, 500000000, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTimestamp(&tc.value)
+			assert.Nil(t, err)
+			assert.True(t, tc.want.Equal(got))
+		})
+	}
+
+	invalid := "2024-01-01 00:30:00"
+	_, err := ParseTimestamp(&invalid)
+	assert.ErrorIs(t, err, ErrInvalidTimestamp)
+
+	_, err = ParseTimestamp(nil)
+	assert.ErrorIs(t, err, ErrInvalidTimestamp)
+}
+
+func TestSessionTimestamps(t *testing.T) {
+	createdAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+
+	session := Session{}
+	session.SetCreatedAt(createdAt)
+	session.SetExpiresAt(expiresAt)
+	assert.Equal(t, "2024-01-01T00:00:00Z", *session.CreatedAt)
+	assert.Equal(t, "2024-01-01T01:00:00Z", *session.ExpiresAt)
+
+	body, err := json.Marshal(session)
+	assert.Nil(t, err)
+	var decoded Session
+	assert.Nil(t, json.Unmarshal(body, &decoded))
+
+	got, err := decoded.CreatedAtTime()
+	assert.Nil(t, err)
+	assert.True(t, createdAt.Equal(got))
+	got, err = decoded.ExpiresAtTime()
+	assert.Nil(t, err)
+	assert.True(t, expiresAt.Equal(got))
+}
+
+func TestUserTimestamps(t *testing.T) {
+	createdAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	user := NewUser("123456", UserWithCreatedAt(createdAt))
+	assert.Equal(t, "2024-01-01T00:00:00Z", *user.CreatedAt)
+
+	got, err := user.CreatedAtTime()
+	assert.Nil(t, err)
+	assert.True(t, createdAt.Equal(got))
+
+	// the string fields can still be set directly
+	legacy := "1970-01-01T00:00:00Z"
+	user = User{ID: "123456", CreatedAt: &legacy}
+	got, err = user.CreatedAtTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), got.Unix())
+}
+
+func ExampleSession_SetCreatedAt() {
+	session := Session{}
+	session.SetCreatedAt(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	fmt.Println(*session.CreatedAt)
+	// Output: 2024-01-01T00:00:00Z
+}
@@ -0,0 +1,350 @@
+package fraudsdkgo
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// TLSFingerprint describes the fingerprints of the TLS ClientHello sent by the emitter.
+type TLSFingerprint struct {
+	JA3 string
+	JA4 string
+}
+
+// tlsFingerprintContextKey is the key used to store the [TLSFingerprint] in the context of the connection.
+type tlsFingerprintContextKey struct{}
+
+// tlsFingerprintHolder stores the [TLSFingerprint] of a connection.
+// It is attached to the context of the connection before the handshake, and filled during the handshake.
+type tlsFingerprintHolder struct {
+	value atomic.Value
+}
+
+// TLSFingerprinter computes the JA3 and JA4 fingerprints of the TLS connections of an [http.Server]
+// and attaches them to the context of the requests.
+// The fingerprints are then automatically added to the [Header] sent to the Account Protect API.
+//
+// The fingerprints require the extensions of the ClientHello, exposed by the standard library since Go 1.24:
+// no fingerprint is computed when the SDK is built with an older version.
+type TLSFingerprinter struct {
+	holders sync.Map
+}
+
+// NewTLSFingerprinter instantiates a new [TLSFingerprinter].
+// Use [TLSFingerprinter.Configure] to plug it into an [http.Server].
+func NewTLSFingerprinter() *TLSFingerprinter {
+	return &TLSFingerprinter{}
+}
+
+// Configure plugs the [TLSFingerprinter] into the [http.Server] by setting the GetConfigForClient function
+// of its TLSConfig, its ConnContext and its ConnState functions.
+// The functions already defined on the server are preserved and called afterwards.
+//
+// It must be called before the server starts.
+func (f *TLSFingerprinter) Configure(srv *http.Server) {
+	if srv.TLSConfig == nil {
+		srv.TLSConfig = &tls.Config{}
+	}
+
+	getConfigForClient := srv.TLSConfig.GetConfigForClient
+	srv.TLSConfig.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		_, _ = f.GetConfigForClient(hello)
+		if getConfigForClient != nil {
+			return getConfigForClient(hello)
+		}
+		return nil, nil
+	}
+
+	connContext := srv.ConnContext
+	srv.ConnContext = func(ctx context.Context, c net.Conn) context.Context {
+		ctx = f.ConnContext(ctx, c)
+		if connContext != nil {
+			return connContext(ctx, c)
+		}
+		return ctx
+	}
+
+	connState := srv.ConnState
+	srv.ConnState = func(c net.Conn, state http.ConnState) {
+		f.ConnState(c, state)
+		if connState != nil {
+			connState(c, state)
+		}
+	}
+}
+
+// GetConfigForClient computes the fingerprints of the ClientHello and stores them for the connection.
+// It can be used as the GetConfigForClient function of a [tls.Config], and always returns a nil [tls.Config].
+func (f *TLSFingerprinter) GetConfigForClient(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	if hello.Conn == nil {
+		return nil, nil
+	}
+	holder, ok := f.holders.LoadAndDelete(hello.Conn)
+	if !ok {
+		return nil, nil
+	}
+	if fingerprint := computeTLSFingerprint(hello); fingerprint != nil {
+		holder.(*tlsFingerprintHolder).value.Store(fingerprint)
+	}
+	return nil, nil
+}
+
+// ConnContext attaches the holder of the fingerprints of the connection to its context.
+// It can be used as the ConnContext function of an [http.Server].
+func (f *TLSFingerprinter) ConnContext(ctx context.Context, c net.Conn) context.Context {
+	holder := &tlsFingerprintHolder{}
+	f.holders.Store(underlyingConn(c), holder)
+	return context.WithValue(ctx, tlsFingerprintContextKey{}, holder)
+}
+
+// ConnState releases the resources associated to the connection once it is closed.
+// It can be used as the ConnState function of an [http.Server].
+func (f *TLSFingerprinter) ConnState(c net.Conn, state http.ConnState) {
+	if state == http.StateClosed || state == http.StateHijacked {
+		f.holders.Delete(underlyingConn(c))
+	}
+}
+
+// underlyingConn returns the connection wrapped by a [tls.Conn], which is the one exposed
+// in the [tls.ClientHelloInfo].
+func underlyingConn(c net.Conn) net.Conn {
+	if tlsConn, ok := c.(*tls.Conn); ok {
+		return tlsConn.NetConn()
+	}
+	return c
+}
+
+// TLSFingerprintFromContext returns the [TLSFingerprint] attached to the context by a [TLSFingerprinter].
+// It returns nil if no fingerprint is available.
+func TLSFingerprintFromContext(ctx context.Context) *TLSFingerprint {
+	holder, ok := ctx.Value(tlsFingerprintContextKey{}).(*tlsFingerprintHolder)
+	if !ok {
+		return nil
+	}
+	fingerprint, _ := holder.value.Load().(*TLSFingerprint)
+	return fingerprint
+}
+
+// clientHello describes the fields of a TLS ClientHello used to compute its fingerprints.
+type clientHello struct {
+	alpn             []string
+	ciphers          []uint16
+	curves           []uint16
+	extensions       []uint16
+	points           []uint8
+	serverName       string
+	signatureSchemes []uint16
+	versions         []uint16
+}
+
+// computeTLSFingerprint returns the [TLSFingerprint] of the ClientHello,
+// or nil if the extensions of the ClientHello are not available.
+func computeTLSFingerprint(hello *tls.ClientHelloInfo) *TLSFingerprint {
+	extensions, ok := clientHelloExtensions(hello)
+	if !ok {
+		return nil
+	}
+	h := &clientHello{
+		alpn:       hello.SupportedProtos,
+		ciphers:    hello.CipherSuites,
+		extensions: extensions,
+		points:     hello.SupportedPoints,
+		serverName: hello.ServerName,
+		versions:   hello.SupportedVersions,
+	}
+	for _, curve := range hello.SupportedCurves {
+		h.curves = append(h.curves, uint16(curve))
+	}
+	for _, scheme := range hello.SignatureSchemes {
+		h.signatureSchemes = append(h.signatureSchemes, uint16(scheme))
+	}
+	return &TLSFingerprint{
+		JA3: h.ja3(),
+		JA4: h.ja4(),
+	}
+}
+
+const (
+	extensionServerName        uint16 = 0x0000
+	extensionALPN              uint16 = 0x0010
+	extensionSupportedVersions uint16 = 0x002b
+)
+
+// isGREASE returns true if the value is a GREASE value as defined in RFC 8701.
+func isGREASE(value uint16) bool {
+	return value&0x0f0f == 0x0a0a && value>>8 == value&0xff
+}
+
+// withoutGREASE returns the values without the GREASE ones.
+func withoutGREASE(values []uint16) []uint16 {
+	result := make([]uint16, 0, len(values))
+	for _, value := range values {
+		if !isGREASE(value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// hasExtension returns true if the ClientHello contains the given extension.
+func (h *clientHello) hasExtension(extension uint16) bool {
+	for _, value := range h.extensions {
+		if value == extension {
+			return true
+		}
+	}
+	return false
+}
+
+// maxVersion returns the highest TLS version supported by the ClientHello.
+func (h *clientHello) maxVersion() uint16 {
+	var version uint16
+	for _, value := range withoutGREASE(h.versions) {
+		if value > version {
+			version = value
+		}
+	}
+	return version
+}
+
+// ja3 returns the JA3 fingerprint of the ClientHello.
+// The legacy version of the ClientHello is not exposed by the standard library: it is `TLS 1.2`
+// when the supported_versions extension is sent, the highest supported version otherwise.
+func (h *clientHello) ja3() string {
+	version := h.maxVersion()
+	if h.hasExtension(extensionSupportedVersions) {
+		version = tls.VersionTLS12
+	}
+	points := make([]uint16, 0, len(h.points))
+	for _, point := range h.points {
+		points = append(points, uint16(point))
+	}
+
+	fields := []string{
+		strconv.Itoa(int(version)),
+		joinDecimal(withoutGREASE(h.ciphers)),
+		joinDecimal(withoutGREASE(h.extensions)),
+		joinDecimal(withoutGREASE(h.curves)),
+		joinDecimal(points),
+	}
+	sum := md5.Sum([]byte(strings.Join(fields, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ja4 returns the JA4 fingerprint of the ClientHello, for a connection over TCP.
+func (h *clientHello) ja4() string {
+	ciphers := withoutGREASE(h.ciphers)
+	extensions := withoutGREASE(h.extensions)
+
+	sni := "i"
+	if h.serverName != "" {
+		sni = "d"
+	}
+	a := fmt.Sprintf("t%s%s%02d%02d%s", ja4Version(h.maxVersion()), sni, min99(len(ciphers)), min99(len(extensions)), ja4ALPN(h.alpn))
+
+	sortedCiphers := append([]uint16{}, ciphers...)
+	sort.Slice(sortedCiphers, func(i, j int) bool { return sortedCiphers[i] < sortedCiphers[j] })
+	b := ja4Hash(joinHex(sortedCiphers))
+
+	sortedExtensions := []uint16{}
+	for _, extension := range extensions {
+		if extension != extensionServerName && extension != extensionALPN {
+			sortedExtensions = append(sortedExtensions, extension)
+		}
+	}
+	sort.Slice(sortedExtensions, func(i, j int) bool { return sortedExtensions[i] < sortedExtensions[j] })
+	c := joinHex(sortedExtensions)
+	if signatureSchemes := withoutGREASE(h.signatureSchemes); len(signatureSchemes) > 0 {
+		c += "_" + joinHex(signatureSchemes)
+	}
+	if len(sortedExtensions) == 0 {
+		c = ""
+	}
+
+	return a + "_" + b + "_" + ja4Hash(c)
+}
+
+// ja4Version returns the representation of the TLS version in the JA4 fingerprint.
+func ja4Version(version uint16) string {
+	switch version {
+	case tls.VersionTLS13:
+		return "13"
+	case tls.VersionTLS12:
+		return "12"
+	case tls.VersionTLS11:
+		return "11"
+	case tls.VersionTLS10:
+		return "10"
+	case 0x0300:
+		return "s3"
+	}
+	return "00"
+}
+
+// ja4ALPN returns the representation of the first ALPN value in the JA4 fingerprint,
+// i.e. its first and last characters, or the first and last characters of its hex representation
+// if they are not alphanumeric.
+func ja4ALPN(alpn []string) string {
+	if len(alpn) == 0 || alpn[0] == "" {
+		return "00"
+	}
+	value := alpn[0]
+	first, last := value[0], value[len(value)-1]
+	if !isAlphanumeric(first) || !isAlphanumeric(last) {
+		encoded := hex.EncodeToString([]byte(value))
+		return encoded[:1] + encoded[len(encoded)-1:]
+	}
+	return string([]byte{first, last})
+}
+
+// ja4Hash returns the first 12 characters of the hex-encoded SHA-256 hash of the value,
+// or `000000000000` if the value is empty.
+func ja4Hash(value string) string {
+	if value == "" {
+		return "000000000000"
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// isAlphanumeric returns true if the byte is an ASCII letter or digit.
+func isAlphanumeric(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// min99 returns the value capped to 99.
+func min99(value int) int {
+	if value > 99 {
+		return 99
+	}
+	return value
+}
+
+// joinDecimal returns the decimal representation of the values separated by dashes.
+func joinDecimal(values []uint16) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, strconv.Itoa(int(value)))
+	}
+	return strings.Join(parts, "-")
+}
+
+// joinHex returns the 4-character hex representation of the values separated by commas.
+func joinHex(values []uint16) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprintf("%04x", value))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,10 @@
+//go:build go1.24
+
+package fraudsdkgo
+
+import "crypto/tls"
+
+// clientHelloExtensions returns the extensions of the ClientHello, in the order they were sent.
+func clientHelloExtensions(hello *tls.ClientHelloInfo) ([]uint16, bool) {
+	return hello.Extensions, true
+}
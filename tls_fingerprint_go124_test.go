@@ -0,0 +1,38 @@
+//go:build go1.24
+
+package fraudsdkgo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLSFingerprinter_Server(t *testing.T) {
+	c, err := NewClient("your-api-key")
+	assert.Nil(t, err)
+
+	var header *Header
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, err = c.buildHeader(r, &RequestMetadata{})
+		fmt.Fprint(w, "ok")
+	}))
+	NewTLSFingerprinter().Configure(srv.Config)
+	srv.TLS = srv.Config.TLSConfig
+	srv.StartTLS()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	assert.NotNil(t, header)
+	assert.NotNil(t, header.JA3)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), *header.JA3)
+	assert.NotNil(t, header.JA4)
+	assert.Regexp(t, regexp.MustCompile(`^t1[0-3][di]\d{4}[0-9a-z]{2}_[0-9a-f]{12}_[0-9a-f]{12}$`), *header.JA4)
+}
@@ -0,0 +1,11 @@
+//go:build !go1.24
+
+package fraudsdkgo
+
+import "crypto/tls"
+
+// clientHelloExtensions returns the extensions of the ClientHello, in the order they were sent.
+// They are not exposed by the standard library before Go 1.24.
+func clientHelloExtensions(hello *tls.ClientHelloInfo) ([]uint16, bool) {
+	return nil, false
+}
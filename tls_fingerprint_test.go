@@ -0,0 +1,93 @@
+package fraudsdkgo
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupClientHello() *clientHello {
+	return &clientHello{
+		alpn:             []string{"h2", "http/1.1"},
+		ciphers:          []uint16{0x0a0a, 0x1301, 0x1302, 0x1303, 0xc02b, 0xc02f},
+		curves:           []uint16{0x2a2a, 29, 23, 24},
+		extensions:       []uint16{0x1a1a, 0x0000, 0x0017, 0x002b, 0x000d, 0x0010, 0x000a, 0x000b, 0xff01},
+		points:           []uint8{0},
+		serverName:       "www.example.com",
+		signatureSchemes: []uint16{0x0403, 0x0804, 0x0401},
+		versions:         []uint16{0x3a3a, tls.VersionTLS13, tls.VersionTLS12},
+	}
+}
+
+func TestClientHello_JA3(t *testing.T) {
+	h := setupClientHello()
+	assert.Equal(t, "11508a0f4a8809421d92e7568cd87942", h.ja3())
+}
+
+func TestClientHello_JA4(t *testing.T) {
+	t.Run("TLS 1.3 with SNI and ALPN", func(t *testing.T) {
+		h := setupClientHello()
+		assert.Equal(t, "t13d0508h2_e133e205ac38_5e5676343554", h.ja4())
+	})
+
+	t.Run("Without SNI, ALPN and extensions", func(t *testing.T) {
+		h := &clientHello{
+			versions: []uint16{tls.VersionTLS12},
+		}
+		assert.Equal(t, "t12i000000_000000000000_000000000000", h.ja4())
+	})
+}
+
+func TestJA4ALPN(t *testing.T) {
+	assert.Equal(t, "00", ja4ALPN(nil))
+	assert.Equal(t, "h2", ja4ALPN([]string{"h2"}))
+	assert.Equal(t, "h1", ja4ALPN([]string{"http/1.1"}))
+	assert.Equal(t, "cb", ja4ALPN([]string{"\xc0\xab"}))
+}
+
+func TestIsGREASE(t *testing.T) {
+	assert.True(t, isGREASE(0x0a0a))
+	assert.True(t, isGREASE(0xfafa))
+	assert.False(t, isGREASE(0x0a1a))
+	assert.False(t, isGREASE(0x1301))
+}
+
+func TestTLSFingerprinter(t *testing.T) {
+	f := NewTLSFingerprinter()
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	ctx := f.ConnContext(context.Background(), serverConn)
+	assert.Nil(t, TLSFingerprintFromContext(ctx))
+
+	f.ConnState(serverConn, http.StateClosed)
+	_, ok := f.holders.Load(serverConn)
+	assert.False(t, ok)
+
+	assert.Nil(t, TLSFingerprintFromContext(context.Background()))
+}
+
+func TestTLSFingerprinter_Configure(t *testing.T) {
+	called := false
+	srv := &http.Server{
+		ConnState: func(c net.Conn, state http.ConnState) {
+			called = true
+		},
+	}
+	NewTLSFingerprinter().Configure(srv)
+
+	assert.NotNil(t, srv.TLSConfig)
+	assert.NotNil(t, srv.TLSConfig.GetConfigForClient)
+	assert.NotNil(t, srv.ConnContext)
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+	srv.ConnState(serverConn, http.StateNew)
+	assert.True(t, called)
+}
@@ -0,0 +1,123 @@
+package fraudsdkgo
+
+import "time"
+
+// UserOption describes the functional option signature to customize the [User] construction.
+type UserOption func(*userBuilder)
+
+// NormalizationMode describes how the normalized email address and phone number are sent.
+type NormalizationMode string
+
+const (
+	// NormalizeAlongside sends the normalized values in the NormalizedEmail and NormalizedPhone fields,
+	// in addition to the raw values.
+	NormalizeAlongside NormalizationMode = "alongside"
+	// NormalizeInstead replaces the raw values of the Email and Phone fields by the normalized ones.
+	NormalizeInstead NormalizationMode = "instead"
+)
+
+// userBuilder stores the [User] being constructed with the normalization settings.
+type userBuilder struct {
+	user          User
+	mode          NormalizationMode
+	defaultRegion string
+}
+
+// UserWithAddress is a functional option to set the [Address] field.
+func UserWithAddress(address Address) UserOption {
+	return func(b *userBuilder) {
+		b.user.Address = &address
+	}
+}
+
+// UserWithCreatedAt is a functional option to set the creation date of the user account.
+func UserWithCreatedAt(createdAt time.Time) UserOption {
+	return func(b *userBuilder) {
+		b.user.SetCreatedAt(createdAt)
+	}
+}
+
+// UserWithEmail is a functional option to set the email address of the user.
+func UserWithEmail(email string) UserOption {
+	return func(b *userBuilder) {
+		b.user.Email = &email
+	}
+}
+
+// UserWithFirstName is a functional option to set the first name of the user.
+func UserWithFirstName(firstName string) UserOption {
+	return func(b *userBuilder) {
+		b.user.FirstName = &firstName
+	}
+}
+
+// UserWithLastName is a functional option to set the last name of the user.
+func UserWithLastName(lastName string) UserOption {
+	return func(b *userBuilder) {
+		b.user.LastName = &lastName
+	}
+}
+
+// UserWithPhone is a functional option to set the phone number of the user.
+func UserWithPhone(phone string) UserOption {
+	return func(b *userBuilder) {
+		b.user.Phone = &phone
+	}
+}
+
+// UserWithNormalization is a functional option to normalize the email address with [NormalizeEmail]
+// and the phone number with [NormalizePhone], using the region as the default region of the national numbers.
+// The values that cannot be normalized are sent unchanged.
+// The option is ignored if the mode is not one of the [NormalizationMode] constants.
+func UserWithNormalization(mode NormalizationMode, region string) UserOption {
+	return func(b *userBuilder) {
+		if mode != NormalizeAlongside && mode != NormalizeInstead {
+			return
+		}
+		b.mode = mode
+		b.defaultRegion = region
+	}
+}
+
+// NewUser instantiates a new [User] with the given identifier.
+func NewUser(id string, options ...UserOption) User {
+	builder := &userBuilder{
+		user: User{
+			ID: id,
+		},
+	}
+
+	// apply functional options
+	for _, opt := range options {
+		opt(builder)
+	}
+
+	if builder.mode != "" {
+		builder.normalize()
+	}
+
+	return builder.user
+}
+
+// normalize sets the normalized email address and phone number of the [User] according to the [NormalizationMode].
+func (b *userBuilder) normalize() {
+	if b.user.Email != nil {
+		if email, err := NormalizeEmail(*b.user.Email); err == nil {
+			b.setNormalized(&b.user.Email, &b.user.NormalizedEmail, email)
+		}
+	}
+	if b.user.Phone != nil {
+		if phone, err := NormalizePhone(*b.user.Phone, b.defaultRegion); err == nil {
+			b.setNormalized(&b.user.Phone, &b.user.NormalizedPhone, phone)
+		}
+	}
+}
+
+// setNormalized stores the normalized value in the raw field or in the normalized field.
+func (b *userBuilder) setNormalized(raw **string, normalized **string, value string) {
+	if b.mode == NormalizeInstead {
+		*raw = &value
+	} else {
+		*normalized = &value
+	}
+}
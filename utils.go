@@ -1,10 +1,15 @@
 package fraudsdkgo
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // ApiFields describes the fields expected for the [AllowedRequestPayload]
@@ -15,21 +20,37 @@ const (
 	AcceptCharset          ApiFields = "AcceptCharset"
 	AcceptEncoding         ApiFields = "AcceptEncoding"
 	AcceptLanguage         ApiFields = "AcceptLanguage"
+	CDNLocation            ApiFields = "CDNLocation"
 	ClientID               ApiFields = "ClientID"
 	Connection             ApiFields = "Connection"
 	ContentType            ApiFields = "ContentType"
+	DNT                    ApiFields = "DNT"
 	From                   ApiFields = "From"
+	HeaderOrder            ApiFields = "HeaderOrder"
+	HeaderSetHash          ApiFields = "HeaderSetHash"
 	Host                   ApiFields = "Host"
+	JA3                    ApiFields = "JA3"
+	JA4                    ApiFields = "JA4"
 	Origin                 ApiFields = "Origin"
+	Priority               ApiFields = "Priority"
 	Referer                ApiFields = "Referer"
 	Request                ApiFields = "Request"
+	SaveData               ApiFields = "SaveData"
 	SecCHDeviceMemory      ApiFields = "SecCHDeviceMemory"
 	SecCHUA                ApiFields = "SecCHUA"
 	SecCHUAArch            ApiFields = "SecCHUAArch"
+	SecCHUABitness         ApiFields = "SecCHUABitness"
+	SecCHUAFormFactors     ApiFields = "SecCHUAFormFactors"
 	SecCHUAFullVersionList ApiFields = "SecCHUAFullVersionList"
 	SecCHUAMobile          ApiFields = "SecCHUAMobile"
 	SecCHUAModel           ApiFields = "SecCHUAModel"
 	SecCHUAPlatform        ApiFields = "SecCHUAPlatform"
+	SecCHUAPlatformVersion ApiFields = "SecCHUAPlatformVersion"
+	SecCHUAWoW64           ApiFields = "SecCHUAWoW64"
+	SecFetchDest           ApiFields = "SecFetchDest"
+	SecFetchMode           ApiFields = "SecFetchMode"
+	SecFetchSite           ApiFields = "SecFetchSite"
+	SecFetchUser           ApiFields = "SecFetchUser"
 	ServerHostname         ApiFields = "ServerHostname"
 	UserAgent              ApiFields = "UserAgent"
 	XForwardedForIP        ApiFields = "XForwardedForIP"
@@ -39,15 +60,15 @@ const (
 // getTruncationSize returns the maximal size allowed for a given [ApiFields]
 func getTruncationSize(key ApiFields) int {
 	switch key {
-	case SecCHDeviceMemory, SecCHUAMobile:
+	case DNT, SaveData, SecCHDeviceMemory, SecCHUAMobile, SecCHUAWoW64, SecFetchUser:
 		return 8
-	case SecCHUAArch:
+	case SecCHUAArch, SecCHUABitness, SecFetchMode, SecFetchSite:
 		return 16
-	case SecCHUAPlatform:
+	case JA3, Priority, SecCHUAPlatform, SecCHUAPlatformVersion, SecFetchDest:
 		return 32
-	case ContentType:
+	case ContentType, HeaderSetHash, JA4, SecCHUAFormFactors:
 		return 64
-	case ClientID, AcceptCharset, AcceptEncoding, Connection, From, SecCHUA, SecCHUAModel, XRealIP:
+	case CDNLocation, ClientID, AcceptCharset, AcceptEncoding, Connection, From, SecCHUA, SecCHUAModel, XRealIP:
 		return 128
 	case AcceptLanguage, SecCHUAFullVersionList:
 		return 256
@@ -57,7 +78,7 @@ func getTruncationSize(key ApiFields) int {
 		return -512
 	case UserAgent:
 		return 768
-	case Referer:
+	case HeaderOrder, Referer:
 		return 1024
 	case Request:
 		return 2048
@@ -66,56 +87,249 @@ func getTruncationSize(key ApiFields) int {
 	return 0
 }
 
-// truncateValue returns the truncated value of the given key.
-// If the value does not need to be truncated, it remains unchanged.
-func truncateValue(key ApiFields, value string) string {
-	if value == "" {
-		return ""
-	}
-
-	limit := getTruncationSize(key)
+// truncateString returns the value truncated to the given number of bytes.
+// A negative limit keeps the end of the value, a positive limit keeps its beginning,
+// and a limit of 0 leaves the value unchanged.
+// The value is never cut in the middle of a UTF-8 sequence, so the result may be shorter than the limit.
+func truncateString(value string, limit int) string {
 	if limit < 0 && len(value) > (-1*limit) {
-		limit *= -1
-		value = value[len(value)-limit:]
+		start := len(value) + limit
+		for start < len(value) && !utf8.RuneStart(value[start]) {
+			start++
+		}
+		value = value[start:]
 	} else if limit > 0 && len(value) > limit {
-		value = value[:limit]
+		end := limit
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		value = value[:end]
 	}
 
 	return value
 }
 
-// truncatePointerValue returns a pointer of the truncated value of the given key.
-// If the value does not need to be truncated, it remains unchanged.
-func truncatePointerValue(key ApiFields, value string) *string {
+// truncator is used to truncate the values of the [Header] while keeping track of the truncated fields.
+// The sizes override the values returned by [getTruncationSize].
+type truncator struct {
+	sizes     map[ApiFields]int
+	truncated []TruncatedField
+}
+
+// value returns the truncated value of the given key and records the truncation if it happened.
+func (t *truncator) value(key ApiFields, value string) string {
+	limit, ok := t.sizes[key]
+	if !ok {
+		limit = getTruncationSize(key)
+	}
+	result := truncateString(value, limit)
+	if len(result) != len(value) {
+		t.truncated = append(t.truncated, TruncatedField{
+			Field:          key,
+			OriginalLength: len(value),
+			Length:         len(result),
+		})
+	}
+	return result
+}
+
+// pointerValue returns a pointer of the truncated value of the given key, or nil if the value is empty.
+func (t *truncator) pointerValue(key ApiFields, value string) *string {
 	var result *string
 	if value != "" {
-		truncatedValue := truncateValue(key, value)
+		truncatedValue := t.value(key, value)
 		result = &truncatedValue
 	}
 	return result
 }
 
-// getClientId retrieves the ClientID from the incoming request.
-// It uses the value of the `X-DataDome-ClientID` if the session by header feature is used.
-// It reads the `DataDome` cookie value otherwise.
-func getClientId(r *http.Request) string {
-	clientIDHeaders := r.Header.Get("x-datadome-clientid")
-	if len(clientIDHeaders) > 0 {
-		return clientIDHeaders
+// parseIP parses an IP address and returns its canonical form.
+// The value may contain a port (e.g. `192.168.1.1:1234` or `[2001:db8::1]:1234`) or be enclosed
+// in brackets for IPv6 addresses. The zone of IPv6 addresses is removed and IPv4-mapped IPv6
+// addresses (e.g. `::ffff:192.168.1.1`) are converted to IPv4 addresses.
+// It returns false if the value is not a valid IP (e.g. an obfuscated identifier or `unknown`).
+func parseIP(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return netip.Addr{}, false
 	}
-
-	cookie, err := r.Cookie("datadome")
-	if err == nil {
-		return cookie.Value
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+			addr, err = netip.ParseAddr(value[1 : len(value)-1])
+		} else if host, _, splitErr := net.SplitHostPort(value); splitErr == nil {
+			addr, err = netip.ParseAddr(host)
+		}
+		if err != nil {
+			return netip.Addr{}, false
+		}
 	}
+	return addr.WithZone("").Unmap(), true
+}
 
-	return ""
+// normalizeIP returns the canonical form of the IP (see [parseIP]), or the value unchanged
+// if it is not a valid IP.
+func normalizeIP(value string) string {
+	if addr, ok := parseIP(value); ok {
+		return addr.String()
+	}
+	return value
 }
 
 // getIP returns the IP of the emitter from the RemoteAddr field of the request.
+// The RemoteAddr may omit the port.
+// An error wrapping [ErrIPNotFound] is returned if the RemoteAddr is not an IP (e.g. Unix sockets).
 func getIP(r *http.Request) (string, error) {
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
-	return ip, err
+	addr, ok := parseIP(r.RemoteAddr)
+	if !ok {
+		return "", fmt.Errorf("%w: invalid RemoteAddr %q", ErrIPNotFound, r.RemoteAddr)
+	}
+	return addr.String(), nil
+}
+
+// parseTrustedProxies converts the IP addresses or CIDR ranges to the list of trusted networks.
+// A single IP address is considered as a network containing only this address.
+//
+// An error wrapping [ErrWrongTrustedProxiesValue] is returned if a value cannot be parsed.
+func parseTrustedProxies(cidrs []string) ([]netip.Prefix, error) {
+	return parseNetworks(cidrs, ErrWrongTrustedProxiesValue)
+}
+
+// parseNetworks converts the IP addresses or CIDR ranges to a list of networks.
+// A single IP address is considered as a network containing only this address.
+//
+// An error wrapping errWrongValue is returned if a value cannot be parsed.
+func parseNetworks(cidrs []string, errWrongValue error) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", errWrongValue, cidr)
+			}
+			addr = addr.WithZone("").Unmap()
+			networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		network, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", errWrongValue, cidr)
+		}
+		networks = append(networks, network.Masked())
+	}
+	return networks, nil
+}
+
+// isTrustedIP returns true if the IP belongs to one of the trusted networks.
+func isTrustedIP(ip netip.Addr, trustedNetworks []netip.Prefix) bool {
+	for _, network := range trustedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedHop describes a hop of the forwarding chain of the request.
+// The host and proto fields are only known when the hop comes from a `Forwarded` header.
+type forwardedHop struct {
+	node  string
+	host  string
+	proto string
+}
+
+// getForwardedChain returns the hops listed in the forwarding header of the request,
+// from the emitter to the last proxy, followed by the IP found in the RemoteAddr field.
+// The chain is read from the `Forwarded` header if useForwarded is set, from the `X-Forwarded-For` header
+// otherwise. The other header is ignored, as it may have been sent by the emitter itself.
+func getForwardedChain(r *http.Request, remoteIP string, useForwarded bool) []forwardedHop {
+	chain := []forwardedHop{}
+	if useForwarded {
+		for _, element := range parseForwardedHeader(r.Header.Values("forwarded")) {
+			chain = append(chain, forwardedHop{node: element.For, host: element.Host, proto: element.Proto})
+		}
+	} else {
+		for _, value := range r.Header.Values("x-forwarded-for") {
+			for _, hop := range strings.Split(value, ",") {
+				if hop = strings.TrimSpace(hop); hop != "" {
+					chain = append(chain, forwardedHop{node: hop})
+				}
+			}
+		}
+	}
+	return append(chain, forwardedHop{node: remoteIP})
+}
+
+// resolveClientHop returns the hop of the forwarding chain describing the emitter of the request.
+// The node of the returned hop is always a valid IP in its canonical form.
+// The forwarding chain is read from a single header (see [getForwardedChain]).
+//
+// Without any trusted proxy or trusted hop configured, it returns the IP of the RemoteAddr field
+// and the forwarding headers are ignored.
+//
+// With trusted hops, it returns the hop located trustedHops positions before the RemoteAddr in the
+// forwarding chain, or the leftmost hop if the chain is shorter.
+//
+// With trusted proxies, it walks the forwarding chain from right to left and returns the first hop
+// that does not belong to a trusted network, or the leftmost hop if all of them are trusted.
+// The values on the left of an invalid entry cannot be trusted: the last valid hop is returned in that case.
+//
+// The host and the protocol of a `Forwarded` element describe the request received by the proxy that
+// appended it. The returned hop is always described by an element appended by a trusted proxy, or by
+// the RemoteAddr field which has neither host nor protocol.
+//
+// When trusted proxies or trusted hops are configured, a RemoteAddr that is not an IP (e.g. a Unix socket)
+// is considered as a trusted local proxy and the forwarding headers are used.
+// An error wrapping [ErrIPNotFound] is returned if no valid IP can be found.
+func resolveClientHop(r *http.Request, trustedNetworks []netip.Prefix, trustedHops int, useForwarded bool) (forwardedHop, error) {
+	remoteIP, err := getIP(r)
+	if len(trustedNetworks) == 0 && trustedHops == 0 {
+		if err != nil {
+			return forwardedHop{}, err
+		}
+		return forwardedHop{node: remoteIP}, nil
+	}
+
+	chain := getForwardedChain(r, remoteIP, useForwarded)
+
+	if trustedHops > 0 {
+		index := len(chain) - 1 - trustedHops
+		if index < 0 {
+			index = 0
+		}
+		hop := chain[index]
+		addr, ok := parseIP(hop.node)
+		if !ok {
+			if remoteIP == "" {
+				return forwardedHop{}, fmt.Errorf("%w: invalid forwarded value %q", ErrIPNotFound, hop.node)
+			}
+			return forwardedHop{node: remoteIP}, nil
+		}
+		hop.node = addr.String()
+		return hop, nil
+	}
+
+	lastValidHop := forwardedHop{node: remoteIP}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if i == len(chain)-1 && remoteIP == "" {
+			// the local proxy behind a Unix socket is trusted
+			continue
+		}
+		addr, ok := parseIP(chain[i].node)
+		if !ok {
+			break
+		}
+		lastValidHop = chain[i]
+		lastValidHop.node = addr.String()
+		if !isTrustedIP(addr, trustedNetworks) {
+			break
+		}
+	}
+	if lastValidHop.node == "" {
+		return forwardedHop{}, err
+	}
+	return lastValidHop, nil
 }
 
 // getProtocol returns the protocol of the request.
@@ -144,10 +358,15 @@ func getURL(r *http.Request) string {
 
 // getPort returns the port requested
 func getPort(r *http.Request) int {
-	if r.Host == "" {
+	return getHostPort(r.Host)
+}
+
+// getHostPort returns the port of the given host, or -1 if the host does not contain a valid port.
+func getHostPort(host string) int {
+	if host == "" {
 		return -1
 	}
-	_, stringPort, err := net.SplitHostPort(r.Host)
+	_, stringPort, err := net.SplitHostPort(host)
 	if err != nil {
 		return -1
 	}
@@ -166,3 +385,9 @@ func useMetadata[T comparable](val1 T, val2 *T) T {
 	}
 	return val1
 }
+
+// hashValue returns the hex-encoded SHA-256 hash of the given value.
+func hashValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
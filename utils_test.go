@@ -4,8 +4,10 @@ import (
 	"crypto/tls"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -19,16 +21,17 @@ func setup() *http.Request {
 	return request
 }
 
-func TestGetClientId_WithSessionByHeader(t *testing.T) {
+func TestResolveClientID_WithSessionByHeader(t *testing.T) {
 	req, _ := http.NewRequest(http.MethodGet, "/this-is-the-way", nil)
 	req.Header.Set("x-datadome-clientid", "123456")
 
-	result := getClientId(req)
+	c, _ := NewClient("your-api-key")
+	result, _, _ := c.ResolveClientID(req)
 
 	assert.Equal(t, "123456", result)
 }
 
-func TestGetClientId_WithCookie(t *testing.T) {
+func TestResolveClientID_WithCookie(t *testing.T) {
 	req, _ := http.NewRequest(http.MethodGet, "/this-is-the-way", nil)
 	cookie := &http.Cookie{
 		Name:  "datadome",
@@ -36,15 +39,17 @@ func TestGetClientId_WithCookie(t *testing.T) {
 	}
 	req.AddCookie(cookie)
 
-	result := getClientId(req)
+	c, _ := NewClient("your-api-key")
+	result, _, _ := c.ResolveClientID(req)
 
 	assert.Equal(t, "some_value", result)
 }
 
-func TestGetClientId_WithoutCookie(t *testing.T) {
+func TestResolveClientID_WithoutCookie(t *testing.T) {
 	req, _ := http.NewRequest(http.MethodGet, "/this-is-the-way", nil)
 
-	result := getClientId(req)
+	c, _ := NewClient("your-api-key")
+	result, _, _ := c.ResolveClientID(req)
 
 	assert.Equal(t, "", result)
 }
@@ -55,6 +60,15 @@ func TestGetIP(t *testing.T) {
 	result, err := getIP(request)
 	assert.Equal(t, "127.0.0.1", result)
 	assert.Equal(t, nil, err)
+
+	request.RemoteAddr = "127.0.0.1"
+	result, err = getIP(request)
+	assert.Equal(t, "127.0.0.1", result)
+	assert.Equal(t, nil, err)
+
+	request.RemoteAddr = "@"
+	_, err = getIP(request)
+	assert.ErrorIs(t, err, ErrIPNotFound)
 }
 
 func TestGetPort(t *testing.T) {
@@ -119,7 +133,7 @@ func TestGetURL(t *testing.T) {
 	assert.Equal(t, "/ping?a=b", result)
 }
 
-func TestTruncateValue(t *testing.T) {
+func TestTruncator_DefaultSizes(t *testing.T) {
 	type Header struct {
 		Key   ApiFields
 		Value string
@@ -133,6 +147,10 @@ func TestTruncateValue(t *testing.T) {
 		input Header
 	}{
 		{want: 8, input: Header{Key: SecCHUAMobile, Value: fakeCommonValue}},
+		{want: 8, input: Header{Key: SecFetchUser, Value: fakeCommonValue}},
+		{want: 16, input: Header{Key: SecFetchSite, Value: fakeCommonValue}},
+		{want: 32, input: Header{Key: SecCHUAPlatformVersion, Value: fakeCommonValue}},
+		{want: 64, input: Header{Key: SecCHUAFormFactors, Value: fakeCommonValue}},
 		{want: 16, input: Header{Key: SecCHUAArch, Value: fakeCommonValue}},
 		{want: 32, input: Header{Key: SecCHUAPlatform, Value: fakeCommonValue}},
 		{want: 64, input: Header{Key: ContentType, Value: fakeCommonValue}},
@@ -147,8 +165,9 @@ func TestTruncateValue(t *testing.T) {
 		{want: 0, input: Header{Key: "SomeHeader", Value: ""}},
 	}
 
+	tr := &truncator{}
 	for _, tc := range tests {
-		got := truncateValue(tc.input.Key, tc.input.Value)
+		got := tr.value(tc.input.Key, tc.input.Value)
 		assert.Equal(t, tc.want, len(got))
 		if tc.input.Key == XForwardedForIP {
 			assert.Equal(t, fakeEndXFFValue, got)
@@ -156,15 +175,6 @@ func TestTruncateValue(t *testing.T) {
 	}
 }
 
-func TestTruncatePointerValue(t *testing.T) {
-	nilPointer := truncatePointerValue(SecCHUA, "")
-	assert.Nil(t, nilPointer)
-
-	notNilPointer := truncatePointerValue(SecCHUA, "some_value")
-	assert.NotNil(t, notNilPointer)
-	assert.Equal(t, "some_value", *notNilPointer)
-}
-
 func TestUseMetadata(t *testing.T) {
 	val1 := "Foo"
 	var val2 *string
@@ -176,3 +186,265 @@ func TestUseMetadata(t *testing.T) {
 	result2 := useMetadata(val1, val2)
 	assert.Equal(t, "Bar", result2)
 }
+
+func TestHashValue(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", hashValue(""))
+	assert.Equal(t, hashValue("mail@example.com"), hashValue("mail@example.com"))
+	assert.NotEqual(t, hashValue("mail@example.com"), hashValue("other@example.com"))
+	assert.Len(t, hashValue("mail@example.com"), 64)
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "2001:db8::/32", "::1"})
+	assert.Nil(t, err)
+	assert.Len(t, networks, 4)
+	assert.True(t, isTrustedIP(netip.MustParseAddr("10.1.2.3"), networks))
+	assert.True(t, isTrustedIP(netip.MustParseAddr("192.168.1.1"), networks))
+	assert.False(t, isTrustedIP(netip.MustParseAddr("192.168.1.2"), networks))
+	assert.True(t, isTrustedIP(netip.MustParseAddr("2001:db8::42"), networks))
+	assert.True(t, isTrustedIP(netip.MustParseAddr("::1"), networks))
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.ErrorIs(t, err, ErrWrongTrustedProxiesValue)
+
+	_, err = parseTrustedProxies([]string{"not-an-ip"})
+	assert.ErrorIs(t, err, ErrWrongTrustedProxiesValue)
+
+	networks, err = parseTrustedProxies([]string{"::ffff:10.0.0.1", "10.1.2.3/8"})
+	assert.Nil(t, err)
+	assert.True(t, isTrustedIP(netip.MustParseAddr("10.0.0.1"), networks))
+	assert.True(t, isTrustedIP(netip.MustParseAddr("10.200.0.1"), networks))
+}
+
+func TestParseIP(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		ok    bool
+	}{
+		{"IPv4", "192.168.1.1", "192.168.1.1", true},
+		{"IPv4 with port", "192.168.1.1:1234", "192.168.1.1", true},
+		{"IPv6", "2001:DB8::0:1", "2001:db8::1", true},
+		{"IPv6 with port", "[2001:db8::1]:1234", "2001:db8::1", true},
+		{"IPv6 in brackets", "[2001:db8::1]", "2001:db8::1", true},
+		{"IPv6 with zone", "fe80::1%eth0", "fe80::1", true},
+		{"IPv6 with zone and port", "[fe80::1%eth0]:1234", "fe80::1", true},
+		{"IPv4-mapped IPv6", "::ffff:192.168.1.1", "192.168.1.1", true},
+		{"IPv4-mapped IPv6 with port", "[::ffff:192.168.1.1]:1234", "192.168.1.1", true},
+		{"Surrounding spaces", " 192.168.1.1 ", "192.168.1.1", true},
+		{"Unix socket", "@", "", false},
+		{"Empty value", "", "", false},
+		{"Obfuscated identifier", "_hidden", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, ok := parseIP(tc.value)
+			assert.Equal(t, tc.ok, ok)
+			if ok {
+				assert.Equal(t, tc.want, addr.String())
+			}
+		})
+	}
+}
+
+func TestResolveClientHop(t *testing.T) {
+	trustedNetworks, _ := parseTrustedProxies([]string{"10.0.0.0/8", "172.16.0.1"})
+
+	tests := []struct {
+		name            string
+		remoteAddr      string
+		xForwardedFor   []string
+		trustedNetworks []netip.Prefix
+		trustedHops     int
+		want            string
+	}{
+		{"Without configuration the RemoteAddr is used", "10.0.0.1:1234", []string{"203.0.113.1"}, nil, 0, "10.0.0.1"},
+		{"RemoteAddr is not trusted", "198.51.100.1:1234", []string{"203.0.113.1"}, trustedNetworks, 0, "198.51.100.1"},
+		{"Single trusted proxy", "10.0.0.1:1234", []string{"203.0.113.1"}, trustedNetworks, 0, "203.0.113.1"},
+		{"Several trusted proxies", "10.0.0.1:1234", []string{"203.0.113.1, 172.16.0.1, 10.0.0.2"}, trustedNetworks, 0, "203.0.113.1"},
+		{"Several X-Forwarded-For headers", "10.0.0.1:1234", []string{"203.0.113.1", "172.16.0.1"}, trustedNetworks, 0, "203.0.113.1"},
+		{"Spoofed leftmost value is skipped", "10.0.0.1:1234", []string{"1.1.1.1, 203.0.113.1"}, trustedNetworks, 0, "203.0.113.1"},
+		{"Spoofed trusted value is not reached", "10.0.0.1:1234", []string{"10.0.0.3, 203.0.113.1"}, trustedNetworks, 0, "203.0.113.1"},
+		{"Spoofed invalid value", "10.0.0.1:1234", []string{"<script>, 10.0.0.2"}, trustedNetworks, 0, "10.0.0.2"},
+		{"All hops are trusted", "10.0.0.1:1234", []string{"10.0.0.3, 10.0.0.2"}, trustedNetworks, 0, "10.0.0.3"},
+		{"Entries with ports", "10.0.0.1:1234", []string{"[2001:db8::1]:4321, 10.0.0.2:80"}, trustedNetworks, 0, "2001:db8::1"},
+		{"Missing X-Forwarded-For", "10.0.0.1:1234", nil, trustedNetworks, 0, "10.0.0.1"},
+		{"One trusted hop", "10.0.0.1:1234", []string{"1.1.1.1, 203.0.113.1"}, nil, 1, "203.0.113.1"},
+		{"Two trusted hops", "10.0.0.1:1234", []string{"1.1.1.1, 203.0.113.1, 10.0.0.2"}, nil, 2, "203.0.113.1"},
+		{"More trusted hops than entries", "10.0.0.1:1234", []string{"203.0.113.1"}, nil, 3, "203.0.113.1"},
+		{"Invalid value at the trusted hop", "10.0.0.1:1234", []string{"unknown"}, nil, 1, "10.0.0.1"},
+		{"RemoteAddr without port", "10.0.0.1", []string{"203.0.113.1"}, trustedNetworks, 0, "203.0.113.1"},
+		{"IPv4-mapped RemoteAddr", "[::ffff:10.0.0.1]:1234", []string{"203.0.113.1"}, trustedNetworks, 0, "203.0.113.1"},
+		{"IPv4-mapped forwarded value", "10.0.0.1:1234", []string{"::ffff:203.0.113.1"}, trustedNetworks, 0, "203.0.113.1"},
+		{"Forwarded value with zone", "10.0.0.1:1234", []string{"fe80::1%eth0"}, trustedNetworks, 0, "fe80::1"},
+		{"Unix socket with trusted proxies", "@", []string{"203.0.113.1, 10.0.0.2"}, trustedNetworks, 0, "203.0.113.1"},
+		{"Unix socket with trusted hops", "@", []string{"1.1.1.1, 203.0.113.1"}, nil, 1, "203.0.113.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			r.RemoteAddr = tc.remoteAddr
+			for _, value := range tc.xForwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			got, err := resolveClientHop(r, tc.trustedNetworks, tc.trustedHops, false)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got.node)
+		})
+	}
+}
+
+func TestResolveClientHop_NotFound(t *testing.T) {
+	trustedNetworks, _ := parseTrustedProxies([]string{"10.0.0.0/8"})
+
+	tests := []struct {
+		name            string
+		xForwardedFor   []string
+		trustedNetworks []netip.Prefix
+		trustedHops     int
+	}{
+		{"Without configuration", []string{"203.0.113.1"}, nil, 0},
+		{"Trusted proxies without X-Forwarded-For", nil, trustedNetworks, 0},
+		{"Trusted proxies with an invalid value", []string{"unknown"}, trustedNetworks, 0},
+		{"Trusted hops with an invalid value", []string{"unknown"}, nil, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			r.RemoteAddr = "@"
+			for _, value := range tc.xForwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			_, err := resolveClientHop(r, tc.trustedNetworks, tc.trustedHops, false)
+			assert.ErrorIs(t, err, ErrIPNotFound)
+		})
+	}
+}
+
+func TestResolveClientHop_WithForwardedHeader(t *testing.T) {
+	trustedNetworks, _ := parseTrustedProxies([]string{"10.0.0.0/8"})
+
+	tests := []struct {
+		name            string
+		forwarded       []string
+		trustedNetworks []netip.Prefix
+		trustedHops     int
+		want            forwardedHop
+	}{
+		{"Without configuration the RemoteAddr is used", []string{"for=203.0.113.1;proto=https;host=example.com"}, nil, 0, forwardedHop{node: "10.0.0.1"}},
+		{"Trusted proxies", []string{"for=1.1.1.1, for=203.0.113.1;proto=https;host=example.com, for=10.0.0.2"}, trustedNetworks, 0, forwardedHop{node: "203.0.113.1", host: "example.com", proto: "https"}},
+		{"Trusted hops", []string{"for=1.1.1.1, for=203.0.113.1;proto=https"}, nil, 1, forwardedHop{node: "203.0.113.1", proto: "https"}},
+		{"Quoted IPv6", []string{`for="[2001:db8:cafe::17]:4711"`}, trustedNetworks, 0, forwardedHop{node: "2001:db8:cafe::17"}},
+		{"Quoted IPv6 without port", []string{`for="[2001:db8:cafe::17]"`}, trustedNetworks, 0, forwardedHop{node: "2001:db8:cafe::17"}},
+		{"Obfuscated identifier", []string{"for=_hidden;proto=https, for=10.0.0.2;proto=http"}, trustedNetworks, 0, forwardedHop{node: "10.0.0.2", proto: "http"}},
+		{"Untrusted RemoteAddr", []string{"for=203.0.113.1;proto=https;host=example.com"}, []netip.Prefix{netip.MustParsePrefix("192.168.0.0/16")}, 0, forwardedHop{node: "10.0.0.1"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			r.Header.Set("X-Forwarded-For", "198.51.100.1")
+			for _, value := range tc.forwarded {
+				r.Header.Add("Forwarded", value)
+			}
+
+			got, err := resolveClientHop(r, tc.trustedNetworks, tc.trustedHops, true)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestResolveClientHop_WithBothHeaders(t *testing.T) {
+	trustedNetworks, _ := parseTrustedProxies([]string{"10.0.0.0/8"})
+
+	tests := []struct {
+		name            string
+		trustedNetworks []netip.Prefix
+		trustedHops     int
+		useForwarded    bool
+		want            forwardedHop
+	}{
+		{"Forged Forwarded with trusted proxies", trustedNetworks, 0, false, forwardedHop{node: "1.2.3.4"}},
+		{"Forged Forwarded with trusted hops", nil, 1, false, forwardedHop{node: "1.2.3.4"}},
+		{"Forged Forwarded without configuration", nil, 0, false, forwardedHop{node: "10.0.0.1"}},
+		{"Forged X-Forwarded-For with trusted proxies", trustedNetworks, 0, true, forwardedHop{node: "8.8.8.8", host: "shop.example.com", proto: "https"}},
+		{"Forged X-Forwarded-For with trusted hops", nil, 1, true, forwardedHop{node: "8.8.8.8", host: "shop.example.com", proto: "https"}},
+		{"Forged X-Forwarded-For without configuration", nil, 0, true, forwardedHop{node: "10.0.0.1"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			r.Header.Set("X-Forwarded-For", "1.2.3.4")
+			r.Header.Set("Forwarded", "for=8.8.8.8;proto=https;host=shop.example.com")
+
+			got, err := resolveClientHop(r, tc.trustedNetworks, tc.trustedHops, tc.useForwarded)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestResolveClientHop_ForgedForwardedElements(t *testing.T) {
+	trustedNetworks, _ := parseTrustedProxies([]string{"10.0.0.0/8"})
+
+	r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	// the emitter prepends a forged element, the trusted proxy appends the real one
+	r.Header.Add("Forwarded", "for=10.0.0.9;proto=https;host=evil.example")
+	r.Header.Add("Forwarded", "for=203.0.113.1;proto=http;host=shop.example.com")
+
+	got, err := resolveClientHop(r, trustedNetworks, 0, true)
+	assert.Nil(t, err)
+	assert.Equal(t, forwardedHop{node: "203.0.113.1", host: "shop.example.com", proto: "http"}, got)
+}
+
+func TestTruncateString_UTF8(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		limit int
+		want  string
+	}{
+		{"ASCII value", "abcdef", 3, "abc"},
+		{"Cut inside a 2-byte sequence", "aéé", 2, "a"},
+		{"Cut after a 2-byte sequence", "aéé", 3, "aé"},
+		{"Cut inside a 4-byte sequence", "ab😀", 5, "ab"},
+		{"Keep the end inside a 2-byte sequence", "ééa", -4, "éa"},
+		{"Keep the end inside a 4-byte sequence", "😀ab", -5, "ab"},
+		{"Value shorter than the limit", "éé", 8, "éé"},
+		{"Without limit", "éé", 0, "éé"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := truncateString(tc.value, tc.limit)
+			assert.Equal(t, tc.want, got)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
+
+func TestTruncator(t *testing.T) {
+	tr := &truncator{sizes: map[ApiFields]int{UserAgent: 4, Referer: 0}}
+
+	assert.Equal(t, "übe", tr.value(UserAgent, "über cool mozilla"))
+	assert.Equal(t, strings.Repeat("a", 2000), tr.value(Referer, strings.Repeat("a", 2000)))
+	assert.Equal(t, strings.Repeat("a", 8), *tr.pointerValue(SecCHUAMobile, strings.Repeat("a", 10)))
+	assert.Equal(t, "fr", tr.value(AcceptLanguage, "fr"))
+	assert.Nil(t, tr.pointerValue(SecCHUA, ""))
+
+	assert.Equal(t, []TruncatedField{
+		{Field: UserAgent, OriginalLength: 18, Length: 4},
+		{Field: SecCHUAMobile, OriginalLength: 10, Length: 8},
+	}, tr.truncated)
+}